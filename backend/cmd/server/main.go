@@ -1,22 +1,38 @@
 package main
 
 import (
-	"log"
 	"net/http"
 
 	"github.com/example/texas-holdem-backend/internal/api"
+	"github.com/example/texas-holdem-backend/internal/logging"
+	"github.com/example/texas-holdem-backend/internal/poker"
 )
 
 func main() {
+	// LOG_LEVEL is debug, info (default), warn, error or quiet.
+	logger, err := logging.FromEnv()
+	if err != nil {
+		logger.Warnf("%v; using info", err)
+	}
+
+	// Warm up lookup tables in the background so the server starts
+	// listening immediately; handlers build them lazily if still missing.
+	go func() {
+		if err := poker.Init(); err != nil {
+			logger.Fatalf("building lookup tables failed: %v", err)
+		}
+		logger.Infof("Lookup tables ready")
+	}()
+
 	mux := http.NewServeMux()
 
 	// API routes
-	api.RegisterRoutes(mux)
+	api.RegisterRoutes(mux, logger)
 
 	addr := ":8080"
-	log.Printf("Starting server on %s\n", addr)
+	logger.Infof("Starting server on %s", addr)
 	if err := http.ListenAndServe(addr, mux); err != nil {
-		log.Fatalf("server failed: %v", err)
+		logger.Fatalf("server failed: %v", err)
 	}
 }
 
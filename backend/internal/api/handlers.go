@@ -2,8 +2,19 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
 	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/example/texas-holdem-backend/internal/logging"
 	"github.com/example/texas-holdem-backend/internal/poker"
 )
 
@@ -13,9 +24,11 @@ type evaluateRequest struct {
 }
 
 type evaluateResponse struct {
-	Category string      `json:"category"`
-	Kickers  []string    `json:"kickers"`
-	Value    poker.HandValue `json:"-"`
+	Category   string          `json:"category"`
+	Kickers    []string        `json:"kickers"`
+	Unused     []string        `json:"unused,omitempty"` // the two cards not in the best hand
+	Percentile float64         `json:"percentile"`       // share (0-1) of all 5-card hands no stronger
+	Value      poker.HandValue `json:"-"`
 }
 
 type winnerRequest struct {
@@ -25,26 +38,118 @@ type winnerRequest struct {
 }
 
 type winnerResponse struct {
-	Winner string `json:"winner"` // "player1", "player2", or "tie"
+	Winner      string   `json:"winner"`                // "player1", "player2", or "tie"
+	SplitReason string   `json:"splitReason,omitempty"` // "both_play_board" or "identical_hands" on a tie
+	Player1Best []string `json:"player1Best"`           // the five cards player1 plays
+	Player2Best []string `json:"player2Best"`           // the five cards player2 plays
 }
 
 type simulateRequest struct {
-	Hole         []string `json:"hole"`         // hero hole (2)
+	Game         string   `json:"game"`         // "holdem" (default) or "omaha"
+	Hole         []string `json:"hole"`         // hero hole (2 for holdem, 4 for omaha)
 	Community    []string `json:"community"`    // 0, 3, 4, 5
 	NumOpponents int      `json:"numOpponents"` // >= 1
 	Trials       int      `json:"trials"`       // e.g. 5000, 10000
+	Antithetic   bool     `json:"antithetic"`   // use antithetic variates (holdem only)
+
+	// VillainRangePct restricts opponents to the top X% of starting hands
+	// (holdem only). Zero means any two cards.
+	VillainRangePct float64 `json:"villainRangePct"`
+
+	// VillainWeightedRange instead puts opponents on a range in weighted
+	// notation such as "AKs, QQ:0.5" (see poker.ParseWeightedRange), with
+	// the same restrictions as VillainRangePct.
+	VillainWeightedRange string `json:"villainWeightedRange"`
+
+	// Seed replays a previous run when set to its seedUsed. Zero picks a
+	// fresh seed.
+	Seed int64 `json:"seed"`
+
+	// ImportanceSampling over-samples decisive runouts (holdem only, not
+	// combinable with antithetic or villainRangePct).
+	ImportanceSampling bool `json:"importanceSampling"`
+
+	// TrackFinish returns finishCounts, the distribution of how many
+	// opponents beat hero (not combinable with villainRangePct or
+	// importanceSampling).
+	TrackFinish bool `json:"trackFinish"`
+
+	// ScoreBuckets returns scoreHistogram, hero's final hand scores in
+	// this many equal-width buckets, weakest first (1-100; not combinable
+	// with villainRangePct or importanceSampling).
+	ScoreBuckets int `json:"scoreBuckets"`
+
+	// TargetMarginPct, if set, stops the simulation once the 95% margin
+	// of error on heroWinPct is at most this many points; trials is then
+	// the maximum.
+	TargetMarginPct float64 `json:"targetMarginPct"`
+
+	// RNG selects the random generator: "std" (default, math/rand) or
+	// "xoshiro256", whose seeded stream does not depend on the Go version.
+	RNG string `json:"rng"`
+
+	// VillainCard is one card known to be in the first opponent's hand;
+	// the rest of it is dealt at random (not combinable with antithetic,
+	// villainRangePct or importanceSampling).
+	VillainCard string `json:"villainCard"`
 }
 
 type simulateResponse struct {
-	HeroWinPct    float64 `json:"heroWinPct"`
-	VillainWinPct float64 `json:"villainWinPct"`
-	TiePct        float64 `json:"tiePct"`
-	TrialsRun     int     `json:"trialsRun"`
+	HeroWinPct     float64 `json:"heroWinPct"`
+	HeroWinLow     float64 `json:"heroWinLow"`  // 95% confidence interval
+	HeroWinHigh    float64 `json:"heroWinHigh"` // for heroWinPct
+	VillainWinPct  float64 `json:"villainWinPct"`
+	TiePct         float64 `json:"tiePct"`
+	TrialsRun      int     `json:"trialsRun"`
+	Method         string  `json:"method"`                   // "monte_carlo" or "exact"
+	SeedUsed       int64   `json:"seedUsed"`                 // 0 for exact results
+	FinishCounts   []int   `json:"finishCounts,omitempty"`   // [k] = trials where k opponents beat hero
+	MarginPct      float64 `json:"marginPct,omitempty"`      // 95% margin of heroWinPct; with targetMarginPct
+	ScoreHistogram []int   `json:"scoreHistogram,omitempty"` // with scoreBuckets
+
+	Debug *simulateDebug `json:"debug,omitempty"` // only with ?debug=true
+}
+
+// simulateDebug describes a single-threaded ?debug=true run.
+type simulateDebug struct {
+	Workers int           `json:"workers"`
+	Samples []trialSample `json:"samples"` // the first debugSampleTrials trials
 }
 
-// RegisterRoutes attaches the REST endpoints to the given mux.
-// It also enables CORS so the Flutter web app can call the API.
-func RegisterRoutes(mux *http.ServeMux) {
+type trialSample struct {
+	Board     []string   `json:"board"`
+	Opponents [][]string `json:"opponents"`
+	Outcome   string     `json:"outcome"` // "win", "loss", or "tie" for hero
+}
+
+// debugSampleTrials is how many trial outcomes a ?debug=true simulation
+// returns.
+const debugSampleTrials = 10
+
+// maxTrials bounds the trials a single request may ask for, so one call
+// cannot tie up the server.
+const maxTrials = 1000000
+
+type startingHandEntry struct {
+	Name   string  `json:"name"`
+	Equity float64 `json:"equity"` // heads-up % vs a random hand
+}
+
+type topHandsResponse struct {
+	Hands []startingHandEntry `json:"hands"`
+}
+
+// API path prefixes. legacyAPIPrefix is deprecated in favour of apiV1Prefix.
+const (
+	apiV1Prefix     = "/api/v1"
+	legacyAPIPrefix = "/api"
+)
+
+// RegisterRoutes attaches the REST endpoints to the given mux under
+// /api/v1, with the unversioned /api paths kept as deprecated aliases.
+// It also enables CORS so the Flutter web app can call the API, and logs
+// every request to logger at debug level.
+func RegisterRoutes(mux *http.ServeMux, logger *logging.Logger) {
 	// Simple CORS wrapper for all API routes.
 	withCORS := func(h http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
@@ -64,101 +169,181 @@ func RegisterRoutes(mux *http.ServeMux) {
 		w.Write([]byte("ok"))
 	})
 
-	mux.HandleFunc("/api/evaluate", withCORS(handleEvaluate))
-	mux.HandleFunc("/api/winner", withCORS(handleWinner))
-	mux.HandleFunc("/api/simulate", withCORS(handleSimulate))
+	// POST bodies must be JSON; anything else gets a clear 415 instead of
+	// a confusing "invalid JSON" error from the decoder.
+	requireJSON := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+				if err != nil || mt != "application/json" {
+					http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+					return
+				}
+			}
+			h(w, r)
+		}
+	}
+
+	withLogging := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			h(w, r)
+			logger.Debugf("%s %s %s", r.Method, r.URL.Path, time.Since(start))
+		}
+	}
+
+	// Unversioned paths are kept as deprecated aliases of /api/v1.
+	deprecated := func(successor string, h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", "<"+successor+">; rel=\"successor-version\"")
+			h(w, r)
+		}
+	}
+
+	routes := map[string]http.HandlerFunc{
+		"/evaluate":           handleEvaluate,
+		"/winner":             handleWinner,
+		"/simulate":           handleSimulate,
+		"/top-hands":          handleTopHands,
+		"/board-texture":      handleBoardTexture,
+		"/equity-curve":       handleEquityCurve,
+		"/min-beating-hand":   handleMinBeatingHand,
+		"/evaluate-draw":      handleEvaluateDraw,
+		"/table-showdown":     handleTableShowdown,
+		"/nut-gap":            handleNutGap,
+		"/evaluate-batch":     handleEvaluateBatch,
+		"/blocker-effect":     handleBlockerEffect,
+		"/range-equity-exact": handleRangeEquityExact,
+		"/hand-vs-range":      handleHandVsRange,
+		"/clean-outs":         handleCleanOuts,
+		"/payout":             handlePayout,
+		"/sse/simulate":       handleSimulateSSE,
+		"/mdf":                handleMDF,
+		"/import-hand":        handleImportHand,
+		"/current-best-odds":  handleCurrentBestOdds,
+		"/equity-sources":     handleEquitySources,
+		"/equity-comparison":  handleEquityComparison,
+		"/play-hand":          handlePlayHand,
+		"/best-bet":           handleBestBet,
+		"/evaluate-state":     handleEvaluateState,
+		"/card-impact":        handleCardImpact,
+		"/run-it-multiple":    handleRunItMultiple,
+		"/features":           handleFeatures,
+		"/showdown-full":      handleShowdownFull,
+		"/wawb":               handleWAWB,
+		"/draws":              handleDraws,
+		"/hand-matrix":        handleHandMatrix,
+		"/validate-hand":      handleValidateHand,
+		"/push-fold":          handlePushFold,
+		"/equity-table":       handleEquityTable,
+		"/odds":               handleOdds,
+		"/equity":             handleEquity,
+		"/evaluate-wild":      handleEvaluateWild,
+		"/range-vs-range":     handleRangeVsRange,
+	}
+	for path, h := range routes {
+		h = withLogging(requireJSON(h))
+		mux.HandleFunc(apiV1Prefix+path, withCORS(h))
+		mux.HandleFunc(legacyAPIPrefix+path, withCORS(deprecated(apiV1Prefix+path, h)))
+	}
 }
 
 func handleEvaluate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+	var req evaluateRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, riverOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cards := append(hole, community...)
+
+	hv, _, unused := poker.SplitBestHand(cards)
+	pct, err := poker.HandPercentile(hv)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	resp := evaluateResponse{
+		Category:   poker.CategoryName(hv.Category),
+		Kickers:    ranksToStrings(hv.Kickers),
+		Unused:     cardsToStrings(unused),
+		Percentile: pct,
+	}
+
+	writeJSON(w, resp)
+}
+
+// handleEvaluateWild is handleEvaluate for hands holding up to
+// poker.MaxWilds "?" wildcards, each standing for the card that makes the
+// best hand.
+func handleEvaluateWild(w http.ResponseWriter, r *http.Request) {
 	var req evaluateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+	if !decodePost(w, r, &req) {
 		return
 	}
 
-	if len(req.Hole) != 2 || len(req.Community) < 0 || len(req.Community) > 5 {
-		http.Error(w, "invalid card counts", http.StatusBadRequest)
+	if len(req.Hole) != 2 || len(req.Community) != 5 {
+		http.Error(w, "must supply exactly 2 hole cards and 5 community cards", http.StatusBadRequest)
 		return
 	}
 
 	var cards []poker.Card
-	for _, s := range append(req.Hole, req.Community...) {
-		c, err := poker.ParseCard(s)
+	for _, s := range append(append([]string{}, req.Hole...), req.Community...) {
+		c, err := poker.ParseCardOrWild(s)
 		if err != nil {
 			http.Error(w, "invalid card: "+err.Error(), http.StatusBadRequest)
 			return
 		}
 		cards = append(cards, c)
 	}
-	if len(cards) != 2+len(req.Community) {
-		http.Error(w, "invalid card count after parse", http.StatusBadRequest)
-		return
-	}
 
-	// Pad community to 5 with dummy? No: evaluation is defined for 7 cards.
-	// Here we expect exactly 7 total (2 + 5).
-	if len(cards) != 7 {
-		http.Error(w, "must supply exactly 2 hole cards and 5 community cards", http.StatusBadRequest)
+	hv, err := poker.EvaluateBestHandWithWilds(cards)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	hv := poker.EvaluateBestHand(cards)
-
-	resp := evaluateResponse{
-		Category: categoryToString(hv.Category),
-		Kickers:  ranksToStrings(hv.Kickers),
+	pct, err := poker.HandPercentile(hv)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	writeJSON(w, resp)
+	writeJSON(w, evaluateResponse{
+		Category:   poker.CategoryName(hv.Category),
+		Kickers:    ranksToStrings(hv.Kickers),
+		Percentile: pct,
+	})
 }
 
 func handleWinner(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		return
-	}
-
 	var req winnerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+	if !decodePost(w, r, &req) {
 		return
 	}
 
-	if len(req.Player1Hole) != 2 || len(req.Player2Hole) != 2 || len(req.Community) != 5 {
-		http.Error(w, "require 2 hole cards for each player and 5 community cards", http.StatusBadRequest)
+	p1Hole, err := parseHole("player1 hole", req.Player1Hole)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	parseCards := func(strs []string) ([]poker.Card, error) {
-		cs := make([]poker.Card, 0, len(strs))
-		for _, s := range strs {
-			c, err := poker.ParseCard(s)
-			if err != nil {
-				return nil, err
-			}
-			cs = append(cs, c)
-		}
-		return cs, nil
-	}
-
-	p1Hole, err := parseCards(req.Player1Hole)
+	p2Hole, err := parseHole("player2 hole", req.Player2Hole)
 	if err != nil {
-		http.Error(w, "invalid player1 hole: "+err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	p2Hole, err := parseCards(req.Player2Hole)
+	community, err := parseBoard(req.Community, riverOnly)
 	if err != nil {
-		http.Error(w, "invalid player2 hole: "+err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	community, err := parseCards(req.Community)
-	if err != nil {
-		http.Error(w, "invalid community: "+err.Error(), http.StatusBadRequest)
+	if err := checkDistinct(p1Hole, p2Hole, community); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -167,150 +352,2229 @@ func handleWinner(w http.ResponseWriter, r *http.Request) {
 	p2Seven := append([]poker.Card{}, p2Hole...)
 	p2Seven = append(p2Seven, community...)
 
-	p1Best := poker.EvaluateBestHand(p1Seven)
-	p2Best := poker.EvaluateBestHand(p2Seven)
+	p1Best, p1Used, _ := poker.SplitBestHand(p1Seven)
+	p2Best, p2Used, _ := poker.SplitBestHand(p2Seven)
 
 	cmp := poker.CompareHandValues(p1Best, p2Best)
-	var winner string
+	resp := winnerResponse{
+		Player1Best: cardsToStrings(p1Used),
+		Player2Best: cardsToStrings(p2Used),
+	}
 	switch {
 	case cmp > 0:
-		winner = "player1"
+		resp.Winner = "player1"
 	case cmp < 0:
-		winner = "player2"
+		resp.Winner = "player2"
 	default:
-		winner = "tie"
+		resp.Winner = "tie"
+		resp.SplitReason = poker.SplitReason(p1Hole, p2Hole, community)
 	}
 
-	writeJSON(w, winnerResponse{Winner: winner})
+	writeJSON(w, resp)
 }
 
 func handleSimulate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+	var req simulateRequest
+	if !decodePost(w, r, &req) {
 		return
 	}
 
-	var req simulateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+	hole, community, opts, err := parseSimulation(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	if len(req.Hole) != 2 {
-		http.Error(w, "hero hole must be 2 cards", http.StatusBadRequest)
+	debug := r.URL.Query().Get("debug") == "true"
+	if debug && (len(opts.VillainRange) > 0 || req.ImportanceSampling) {
+		http.Error(w, "debug cannot be combined with a villain range or importanceSampling", http.StatusBadRequest)
 		return
 	}
-	if !(len(req.Community) == 0 || len(req.Community) == 3 || len(req.Community) == 4 || len(req.Community) == 5) {
-		http.Error(w, "community must be 0, 3, 4, or 5 cards", http.StatusBadRequest)
+	if debug {
+		opts.Workers = 1
+		opts.SampleTrials = debugSampleTrials
+	}
+
+	// Heads-up on the turn or river is small enough to enumerate exactly.
+	// Debug runs always simulate so there are trials to sample.
+	var res poker.SimulationResult
+	if opts.Game == poker.Holdem && len(opts.VillainRange) == 0 && len(opts.VillainCards) == 0 && !opts.TrackFinish && opts.ScoreBuckets == 0 && !debug && poker.CanEnumerate(community, req.NumOpponents) {
+		res = poker.EnumerateEquity(hole, community)
+	} else {
+		res = poker.SimulateEquityWithOptions(hole, community, req.NumOpponents, req.Trials, opts)
+	}
+	if res.TrialsRun == 0 {
+		http.Error(w, "no valid deals: villain range conflicts with known cards", http.StatusBadRequest)
 		return
 	}
+
+	resp := simulateResponseFor(res, opts)
+	if debug {
+		resp.Debug = &simulateDebug{Workers: 1, Samples: make([]trialSample, len(res.Samples))}
+		for i, s := range res.Samples {
+			ts := trialSample{Board: cardsToStrings(s.Board)}
+			for _, opp := range s.Opponents {
+				ts.Opponents = append(ts.Opponents, cardsToStrings(opp))
+			}
+			switch {
+			case s.BeatenBy > 0:
+				ts.Outcome = "loss"
+			case s.TiedWith > 0:
+				ts.Outcome = "tie"
+			default:
+				ts.Outcome = "win"
+			}
+			resp.Debug.Samples[i] = ts
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+// parseSimulation validates a simulate request and returns hero's cards and
+// the simulation options it asks for.
+func parseSimulation(req simulateRequest) (hole, community []poker.Card, opts poker.SimulationOptions, err error) {
+	if v := poker.ValidateHand(req.Game, req.Hole, req.Community); len(v) > 0 {
+		return nil, nil, opts, errors.New(v[0].Message)
+	}
+	game, _ := poker.ParseGame(req.Game)
 	if req.NumOpponents < 1 {
-		http.Error(w, "numOpponents must be >= 1", http.StatusBadRequest)
-		return
+		return nil, nil, opts, fmt.Errorf("numOpponents must be >= 1")
+	}
+	if req.NumOpponents > game.MaxOpponents() {
+		return nil, nil, opts, fmt.Errorf("numOpponents must be <= %d for %s", game.MaxOpponents(), game)
+	}
+	if req.Antithetic && game != poker.Holdem {
+		return nil, nil, opts, fmt.Errorf("antithetic sampling is only supported for holdem")
+	}
+	if req.VillainRangePct < 0 || req.VillainRangePct > 100 {
+		return nil, nil, opts, fmt.Errorf("villainRangePct must be between 0 and 100")
+	}
+	if req.VillainRangePct > 0 && req.VillainWeightedRange != "" {
+		return nil, nil, opts, fmt.Errorf("give villainRangePct or villainWeightedRange, not both")
+	}
+	ranged := req.VillainRangePct > 0 || req.VillainWeightedRange != ""
+	if ranged && (game != poker.Holdem || req.Antithetic) {
+		return nil, nil, opts, fmt.Errorf("villain ranges are only supported for holdem without antithetic sampling")
+	}
+	if req.ImportanceSampling && (game != poker.Holdem || req.Antithetic || ranged) {
+		return nil, nil, opts, fmt.Errorf("importanceSampling is only supported for holdem without antithetic sampling or a villain range")
+	}
+	if req.ScoreBuckets < 0 || req.ScoreBuckets > 100 {
+		return nil, nil, opts, fmt.Errorf("scoreBuckets must be between 0 and 100")
+	}
+	if req.ScoreBuckets > 0 && (ranged || req.ImportanceSampling) {
+		return nil, nil, opts, fmt.Errorf("scoreBuckets cannot be combined with a villain range or importanceSampling")
+	}
+	if req.TrackFinish && (ranged || req.ImportanceSampling) {
+		return nil, nil, opts, fmt.Errorf("trackFinish cannot be combined with a villain range or importanceSampling")
+	}
+	if req.Trials <= 0 || req.Trials > maxTrials {
+		return nil, nil, opts, fmt.Errorf("trials must be between 1 and %d", maxTrials)
+	}
+	if req.TargetMarginPct < 0 {
+		return nil, nil, opts, fmt.Errorf("targetMarginPct must be >= 0")
+	}
+	if req.VillainCard != "" && (req.Antithetic || ranged || req.ImportanceSampling) {
+		return nil, nil, opts, fmt.Errorf("villainCard cannot be combined with antithetic, a villain range or importanceSampling")
+	}
+	var newRNG func(int64) poker.RNG
+	switch req.RNG {
+	case "", "std":
+	case "xoshiro256":
+		newRNG = poker.NewXoshiro256
+	default:
+		return nil, nil, opts, fmt.Errorf("unknown rng: %s", req.RNG)
+	}
+
+	hole, err = parseCards(req.Hole)
+	if err != nil {
+		return nil, nil, opts, fmt.Errorf("invalid hero hole: %v", err)
+	}
+	community, err = parseCards(req.Community)
+	if err != nil {
+		return nil, nil, opts, fmt.Errorf("invalid community: %v", err)
+	}
+
+	var villainCards []poker.Card
+	if req.VillainCard != "" {
+		c, err := poker.ParseCard(req.VillainCard)
+		if err != nil {
+			return nil, nil, opts, fmt.Errorf("invalid villainCard: %v", err)
+		}
+		if err := checkDistinct([]poker.Card{c}, hole, community); err != nil {
+			return nil, nil, opts, err
+		}
+		villainCards = []poker.Card{c}
+	}
+
+	opts = poker.SimulationOptions{
+		Game:         game,
+		VillainCards: villainCards,
+		Antithetic:   req.Antithetic,
+		Seed:         req.Seed,
+
+		ImportanceSampling: req.ImportanceSampling,
+		TrackFinish:        req.TrackFinish,
+		ScoreBuckets:       req.ScoreBuckets,
+		TargetMarginPct:    req.TargetMarginPct,
+		NewRNG:             newRNG,
+	}
+	if req.VillainRangePct > 0 {
+		opts.VillainRange = poker.TopPercentRange(req.VillainRangePct)
+	}
+	if req.VillainWeightedRange != "" {
+		r, err := poker.ParseWeightedRange(req.VillainWeightedRange)
+		if err != nil {
+			return nil, nil, opts, fmt.Errorf("invalid villainWeightedRange: %v", err)
+		}
+		opts.VillainRange, opts.VillainWeights = r.Combos, r.Weights
+	}
+	if ranged && poker.RangeComboCount(opts.VillainRange, append(append([]poker.Card{}, hole...), community...)) == 0 {
+		return nil, nil, opts, fmt.Errorf("every villain combo conflicts with known cards")
 	}
-	if req.Trials <= 0 {
-		http.Error(w, "trials must be > 0", http.StatusBadRequest)
+	return hole, community, opts, nil
+}
+
+type validateHandRequest struct {
+	Game      string   `json:"game"` // "holdem" (default) or "omaha"
+	Hole      []string `json:"hole"`
+	Community []string `json:"community"`
+}
+
+type validateHandResponse struct {
+	Valid      bool                  `json:"valid"`
+	Violations []poker.HandViolation `json:"violations"`
+}
+
+func handleValidateHand(w http.ResponseWriter, r *http.Request) {
+	var req validateHandRequest
+	if !decodePost(w, r, &req) {
 		return
 	}
 
-	parseCards := func(strs []string) ([]poker.Card, error) {
-		cs := make([]poker.Card, 0, len(strs))
-		for _, s := range strs {
-			c, err := poker.ParseCard(s)
-			if err != nil {
-				return nil, err
-			}
-			cs = append(cs, c)
+	violations := poker.ValidateHand(req.Game, req.Hole, req.Community)
+	writeJSON(w, validateHandResponse{
+		Valid:      len(violations) == 0,
+		Violations: append([]poker.HandViolation{}, violations...),
+	})
+}
+
+// simulateResponseFor reports a (possibly partial) simulation result.
+func simulateResponseFor(res poker.SimulationResult, opts poker.SimulationOptions) simulateResponse {
+	heroWin, villainWin, tie := res.Rates()
+	resp := simulateResponse{
+		HeroWinPct:     heroWin * 100.0,
+		VillainWinPct:  villainWin * 100.0,
+		TiePct:         tie * 100.0,
+		TrialsRun:      res.TrialsRun,
+		Method:         res.Method,
+		SeedUsed:       res.Seed,
+		FinishCounts:   res.FinishCounts,
+		ScoreHistogram: res.ScoreHistogram,
+	}
+	resp.HeroWinLow, resp.HeroWinHigh = res.HeroWinInterval()
+	if opts.TargetMarginPct > 0 && res.Method == poker.MethodMonteCarlo {
+		resp.MarginPct = res.MarginPct()
+	}
+	return resp
+}
+
+// handleSimulateSSE runs the same simulation as handleSimulate but streams
+// it as Server-Sent Events: a "progress" event with the running result
+// after every batch of trials, then a "result" (or "error") event. It
+// always simulates, never enumerates. The run stops early if the client
+// goes away.
+//
+// Besides a POSTed JSON body it accepts a GET with the request in query
+// parameters (see simulateRequestFromQuery), since a browser EventSource
+// can only send GETs.
+func handleSimulateSSE(w http.ResponseWriter, r *http.Request) {
+	var req simulateRequest
+	switch r.Method {
+	case http.MethodGet:
+		var err error
+		if req, err = simulateRequestFromQuery(r.URL.Query()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			return
 		}
-		return cs, nil
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
 	}
 
-	hole, err := parseCards(req.Hole)
+	hole, community, opts, err := parseSimulation(req)
 	if err != nil {
-		http.Error(w, "invalid hero hole: "+err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
 		return
 	}
-	community, err := parseCards(req.Community)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	opts.Progress = func(partial poker.SimulationResult) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		writeEvent(w, "progress", simulateResponseFor(partial, opts))
+		flusher.Flush()
+		return true
+	}
+	res := poker.SimulateEquityWithOptions(hole, community, req.NumOpponents, req.Trials, opts)
+	if ctx.Err() != nil {
+		return
+	}
+
+	if res.TrialsRun == 0 {
+		writeEvent(w, "error", "no valid deals: villain range conflicts with known cards")
+	} else {
+		writeEvent(w, "result", simulateResponseFor(res, opts))
+	}
+	flusher.Flush()
+}
+
+// simulateRequestFromQuery reads a simulateRequest from query parameters
+// named like its JSON fields, with card lists comma-separated:
+// ?hole=Ah,Kh&community=2c,7d,9h&numOpponents=2&trials=20000.
+func simulateRequestFromQuery(q url.Values) (simulateRequest, error) {
+	var req simulateRequest
+	v := reflect.ValueOf(&req).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("json")
+		s := q.Get(name)
+		if s == "" {
+			continue
+		}
+		var err error
+		switch f := v.Field(i); f.Kind() {
+		case reflect.String:
+			f.SetString(s)
+		case reflect.Slice:
+			f.Set(reflect.ValueOf(strings.Split(s, ",")))
+		case reflect.Bool:
+			var b bool
+			b, err = strconv.ParseBool(s)
+			f.SetBool(b)
+		case reflect.Int, reflect.Int64:
+			var n int64
+			n, err = strconv.ParseInt(s, 10, 64)
+			f.SetInt(n)
+		case reflect.Float64:
+			var x float64
+			x, err = strconv.ParseFloat(s, 64)
+			f.SetFloat(x)
+		}
+		if err != nil {
+			return req, fmt.Errorf("invalid %s: %q", name, s)
+		}
+	}
+	return req, nil
+}
+
+// writeEvent writes v as a JSON Server-Sent Event of the given type.
+func writeEvent(w http.ResponseWriter, event string, v any) {
+	data, err := json.Marshal(v)
 	if err != nil {
-		http.Error(w, "invalid community: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
 
-	res := poker.SimulateEquity(hole, community, req.NumOpponents, req.Trials)
+func handleTopHands(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
 
-	total := float64(res.TrialsRun)
-	resp := simulateResponse{
-		HeroWinPct:    float64(res.HeroWins) / total * 100.0,
-		VillainWinPct: float64(res.VillainWins) / total * 100.0,
-		TiePct:        float64(res.Ties) / total * 100.0,
-		TrialsRun:     res.TrialsRun,
+	count := 10
+	if v := r.URL.Query().Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 169 {
+			http.Error(w, "count must be between 1 and 169", http.StatusBadRequest)
+			return
+		}
+		count = n
+	}
+
+	top := poker.TopStartingHands(count)
+	resp := topHandsResponse{Hands: make([]startingHandEntry, len(top))}
+	for i, h := range top {
+		resp.Hands[i] = startingHandEntry{Name: h.Name, Equity: h.Equity}
 	}
 
 	writeJSON(w, resp)
 }
 
-func writeJSON(w http.ResponseWriter, v any) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(v)
+// Trial limits for /hand-matrix, per starting hand class.
+const (
+	defaultMatrixTrials = 2000
+	maxMatrixTrials     = 10000
+)
+
+type handMatrixResponse struct {
+	Names     [13][13]string  `json:"names"`     // rows and columns A..2; suited above the diagonal
+	EquityPct [13][13]float64 `json:"equityPct"` // heads-up equity of each class
 }
 
-func categoryToString(cat int) string {
-	switch cat {
-	case poker.StraightFlush:
-		return "Straight Flush"
-	case poker.FourOfAKind:
-		return "Four of a Kind"
-	case poker.FullHouse:
-		return "Full House"
-	case poker.Flush:
-		return "Flush"
-	case poker.Straight:
-		return "Straight"
-	case poker.ThreeOfAKind:
-		return "Three of a Kind"
-	case poker.TwoPair:
-		return "Two Pair"
-	case poker.OnePair:
-		return "One Pair"
-	default:
-		return "High Card"
+func handleHandMatrix(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
 	}
-}
 
-func ranksToStrings(rs []poker.Rank) []string {
-	out := make([]string, len(rs))
-	for i, r := range rs {
-		switch r {
-		case poker.Two:
-			out[i] = "2"
-		case poker.Three:
-			out[i] = "3"
-		case poker.Four:
-			out[i] = "4"
-		case poker.Five:
-			out[i] = "5"
-		case poker.Six:
-			out[i] = "6"
-		case poker.Seven:
-			out[i] = "7"
-		case poker.Eight:
-			out[i] = "8"
-		case poker.Nine:
-			out[i] = "9"
-		case poker.Ten:
-			out[i] = "T"
-		case poker.Jack:
-			out[i] = "J"
-		case poker.Queen:
-			out[i] = "Q"
-		case poker.King:
-			out[i] = "K"
-		case poker.Ace:
-			out[i] = "A"
-		default:
-			out[i] = "?"
+	var pct float64
+	if v := r.URL.Query().Get("villainRangePct"); v != "" {
+		p, err := strconv.ParseFloat(v, 64)
+		if err != nil || p < 0 || p > 100 {
+			http.Error(w, "villainRangePct must be between 0 and 100", http.StatusBadRequest)
+			return
 		}
+		pct = p
 	}
-	return out
+	trials := defaultMatrixTrials
+	if v := r.URL.Query().Get("trials"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxMatrixTrials {
+			http.Error(w, fmt.Sprintf("trials must be between 1 and %d", maxMatrixTrials), http.StatusBadRequest)
+			return
+		}
+		trials = n
+	}
+
+	m := poker.RangeHandMatrix(pct, trials)
+	writeJSON(w, handMatrixResponse{Names: m.Names, EquityPct: m.Equity})
+}
+
+type equityCurveRequest struct {
+	Hole         []string `json:"hole"`         // hero hole (2)
+	Community    []string `json:"community"`    // 0, 3, 4, 5
+	MaxOpponents int      `json:"maxOpponents"` // 1-22, default 8
+	Trials       int      `json:"trials"`       // per opponent count
+}
+
+type equityCurvePoint struct {
+	NumOpponents  int     `json:"numOpponents"`
+	HeroWinPct    float64 `json:"heroWinPct"`
+	VillainWinPct float64 `json:"villainWinPct"`
+	TiePct        float64 `json:"tiePct"`
+}
+
+type equityCurveResponse struct {
+	Points []equityCurvePoint `json:"points"`
 }
 
+func handleEquityCurve(w http.ResponseWriter, r *http.Request) {
+	var req equityCurveRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.MaxOpponents == 0 {
+		req.MaxOpponents = 8
+	}
+	if req.MaxOpponents < 1 || req.MaxOpponents > poker.Holdem.MaxOpponents() {
+		http.Error(w, fmt.Sprintf("maxOpponents must be between 1 and %d", poker.Holdem.MaxOpponents()), http.StatusBadRequest)
+		return
+	}
+	if req.Trials <= 0 || req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be between 1 and %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, anyStreet)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := equityCurveResponse{Points: make([]equityCurvePoint, 0, req.MaxOpponents)}
+	for i, res := range poker.EquityCurve(hole, community, req.MaxOpponents, req.Trials) {
+		total := float64(res.TrialsRun)
+		resp.Points = append(resp.Points, equityCurvePoint{
+			NumOpponents:  i + 1,
+			HeroWinPct:    float64(res.HeroWins) / total * 100.0,
+			VillainWinPct: float64(res.VillainWins) / total * 100.0,
+			TiePct:        float64(res.Ties) / total * 100.0,
+		})
+	}
+
+	writeJSON(w, resp)
+}
+
+type equityTableRequest struct {
+	Hole         []string  `json:"hole"`         // hero hole (2)
+	Community    []string  `json:"community"`    // 0, 3, 4, 5
+	MaxOpponents int       `json:"maxOpponents"` // 1-22, default 8
+	Trials       int       `json:"trials"`       // per opponent count
+	BetFractions []float64 `json:"betFractions"` // bet sizes as pot fractions; default poker.DefaultBetFractions
+}
+
+type equityTableRow struct {
+	BetFraction float64  `json:"betFraction"`
+	Actions     []string `json:"actions"` // "call" or "fold" per opponent count
+}
+
+type equityTableResponse struct {
+	Opponents []int            `json:"opponents"` // column headers: 1..maxOpponents
+	EquityPct []float64        `json:"equityPct"` // ties count half
+	Rows      []equityTableRow `json:"rows"`
+}
+
+func handleEquityTable(w http.ResponseWriter, r *http.Request) {
+	var req equityTableRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.MaxOpponents == 0 {
+		req.MaxOpponents = 8
+	}
+	if req.MaxOpponents < 1 || req.MaxOpponents > poker.Holdem.MaxOpponents() {
+		http.Error(w, fmt.Sprintf("maxOpponents must be between 1 and %d", poker.Holdem.MaxOpponents()), http.StatusBadRequest)
+		return
+	}
+	if req.Trials <= 0 || req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be between 1 and %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+	for _, f := range req.BetFractions {
+		if f <= 0 {
+			http.Error(w, "betFractions must be > 0", http.StatusBadRequest)
+			return
+		}
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, anyStreet)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t := poker.BuildEquityTable(hole, community, req.MaxOpponents, req.Trials, req.BetFractions)
+	resp := equityTableResponse{}
+	for i, eq := range t.Equity {
+		resp.Opponents = append(resp.Opponents, i+1)
+		resp.EquityPct = append(resp.EquityPct, eq*100.0)
+	}
+	for i, f := range t.BetFractions {
+		resp.Rows = append(resp.Rows, equityTableRow{BetFraction: f, Actions: t.Actions[i]})
+	}
+
+	writeJSON(w, resp)
+}
+
+type minBeatingHandRequest struct {
+	Community    []string `json:"community"`    // 5 cards
+	OpponentHole []string `json:"opponentHole"` // 2 cards
+}
+
+type minBeatingHandResponse struct {
+	Found    bool     `json:"found"` // false if the opponent holds the nuts
+	Hole     []string `json:"hole,omitempty"`
+	Category string   `json:"category,omitempty"`
+	Kickers  []string `json:"kickers,omitempty"`
+}
+
+func handleMinBeatingHand(w http.ResponseWriter, r *http.Request) {
+	var req minBeatingHandRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	community, err := parseBoard(req.Community, riverOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	oppHole, err := parseHole("opponent hole", req.OpponentHole)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(community, oppHole); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hole, hv, ok := poker.MinBeatingHand(community, oppHole)
+	if !ok {
+		writeJSON(w, minBeatingHandResponse{Found: false})
+		return
+	}
+
+	writeJSON(w, minBeatingHandResponse{
+		Found:    true,
+		Hole:     cardsToStrings(hole[:]),
+		Category: poker.CategoryName(hv.Category),
+		Kickers:  ranksToStrings(hv.Kickers),
+	})
+}
+
+type evaluateDrawRequest struct {
+	Cards []string `json:"cards"` // exactly 5 cards
+}
+
+func handleEvaluateDraw(w http.ResponseWriter, r *http.Request) {
+	var req evaluateDrawRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if len(req.Cards) != 5 {
+		http.Error(w, "must supply exactly 5 cards", http.StatusBadRequest)
+		return
+	}
+
+	cards, err := parseCards(req.Cards)
+	if err != nil {
+		http.Error(w, "invalid card: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(cards); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hv := poker.EvaluateFiveCardDraw(cards)
+	pct, err := poker.HandPercentile(hv)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, evaluateResponse{
+		Category:   poker.CategoryName(hv.Category),
+		Kickers:    ranksToStrings(hv.Kickers),
+		Percentile: pct,
+	})
+}
+
+type tableShowdownRequest struct {
+	Players   [][]string `json:"players"`   // 2-9 seats, 2 hole cards each; null or [] if folded
+	Community []string   `json:"community"` // 5 cards
+}
+
+type seatResult struct {
+	Seat        int      `json:"seat"`  // 1-based position in the request
+	Place       int      `json:"place"` // 1 = best; tied seats share a place
+	Category    string   `json:"category"`
+	Kickers     []string `json:"kickers"`
+	Description string   `json:"description"`
+	BestFive    []string `json:"bestFive"` // the five cards the seat plays
+}
+
+type tableShowdownResponse struct {
+	Results []seatResult `json:"results"`          // ordered best to worst
+	Ranking [][]int      `json:"ranking"`          // tie groups of seats, best first
+	Folded  []int        `json:"folded,omitempty"` // seats left out of the showdown
+}
+
+func handleTableShowdown(w http.ResponseWriter, r *http.Request) {
+	var req tableShowdownRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if len(req.Players) < 2 || len(req.Players) > 9 {
+		http.Error(w, "require between 2 and 9 players", http.StatusBadRequest)
+		return
+	}
+
+	community, err := parseBoard(req.Community, riverOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	holes, err := parseSeats(req.Players)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(append(slices.Concat(holes...), community...)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var folded []int
+	for i, h := range holes {
+		if len(h) == 0 {
+			folded = append(folded, i+1)
+		}
+	}
+
+	groups := poker.RankHands(holes, community)
+	resp := tableShowdownResponse{Folded: folded}
+	place := 1
+	for _, g := range groups {
+		seats := make([]int, len(g))
+		for i, p := range g {
+			seats[i] = p + 1
+			seven := append(append([]poker.Card{}, holes[p]...), community...)
+			hv, used, _ := poker.SplitBestHand(seven)
+			resp.Results = append(resp.Results, seatResult{
+				Seat:        p + 1,
+				Place:       place,
+				Category:    poker.CategoryName(hv.Category),
+				Kickers:     ranksToStrings(hv.Kickers),
+				Description: poker.DescribeHand(hv),
+				BestFive:    cardsToStrings(used),
+			})
+		}
+		resp.Ranking = append(resp.Ranking, seats)
+		place += len(g)
+	}
+
+	writeJSON(w, resp)
+}
+
+type nutGapRequest struct {
+	Hole      []string `json:"hole"`      // 2 cards
+	Community []string `json:"community"` // 5 cards
+}
+
+type nutGapResponse struct {
+	Gap         int    `json:"gap"` // distinct hand values that beat hero; 0 = the nuts
+	HeroHand    string `json:"heroHand"`
+	NutCategory string `json:"nutCategory"`
+	NutHand     string `json:"nutHand"`
+}
+
+func handleNutGap(w http.ResponseWriter, r *http.Request) {
+	var req nutGapRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, riverOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	gap, nuts := poker.NutGap(hole, community)
+	writeJSON(w, nutGapResponse{
+		Gap:         gap,
+		HeroHand:    poker.DescribeHand(poker.Holdem.BestHand(hole, community)),
+		NutCategory: poker.CategoryName(nuts.Category),
+		NutHand:     poker.DescribeHand(nuts),
+	})
+}
+
+type blockerEffectRequest struct {
+	Hole      []string `json:"hole"`      // hero hole (2)
+	Community []string `json:"community"` // 0, 3, 4, 5
+	Blocker   string   `json:"blocker"`   // card removed from villain's range
+	Trials    int      `json:"trials"`    // per equity estimate
+	Seed      int64    `json:"seed"`      // zero picks a fresh seed
+}
+
+type blockerEffectResponse struct {
+	VillainCombos        int     `json:"villainCombos"`
+	BlockedVillainCombos int     `json:"blockedVillainCombos"`
+	EquityPct            float64 `json:"equityPct"`
+	BlockedEquityPct     float64 `json:"blockedEquityPct"`
+	EquityChangePct      float64 `json:"equityChangePct"` // blocked minus unblocked
+
+	// Villain holdings per hand category; only on a 5-card board.
+	CategoryCombos        map[string]int `json:"categoryCombos,omitempty"`
+	BlockedCategoryCombos map[string]int `json:"blockedCategoryCombos,omitempty"`
+}
+
+func handleBlockerEffect(w http.ResponseWriter, r *http.Request) {
+	var req blockerEffectRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.Trials <= 0 || req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be between 1 and %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, anyStreet)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	blocker, err := poker.ParseCard(req.Blocker)
+	if err != nil {
+		http.Error(w, "invalid blocker: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct([]poker.Card{blocker}, hole, community); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	e := poker.CardRemovalEffect(hole, community, blocker, req.Trials, req.Seed)
+	writeJSON(w, blockerEffectResponse{
+		VillainCombos:         e.Combos,
+		BlockedVillainCombos:  e.BlockedCombos,
+		EquityPct:             e.Equity * 100.0,
+		BlockedEquityPct:      e.BlockedEquity * 100.0,
+		EquityChangePct:       (e.BlockedEquity - e.Equity) * 100.0,
+		CategoryCombos:        categoryCountMap(e.CategoryCombos),
+		BlockedCategoryCombos: categoryCountMap(e.BlockedCategoryCombos),
+	})
+}
+
+// categoryCountMap keys per-category counts by category name, dropping
+// empty categories.
+func categoryCountMap(counts []int) map[string]int {
+	if counts == nil {
+		return nil
+	}
+	out := make(map[string]int)
+	for cat, n := range counts {
+		if n > 0 {
+			out[poker.CategoryName(cat)] = n
+		}
+	}
+	return out
+}
+
+type rangeEquityExactRequest struct {
+	Hole      []string `json:"hole"`      // hero hole (2)
+	Community []string `json:"community"` // 5 cards
+
+	// Villain's range, as explicit 2-card combos and/or the top X% of
+	// starting hands. Duplicate combos count once per occurrence.
+	VillainRange    [][]string `json:"villainRange"`
+	VillainRangePct float64    `json:"villainRangePct"`
+}
+
+type rangeEquityExactResponse struct {
+	HeroWinPct    float64 `json:"heroWinPct"`
+	VillainWinPct float64 `json:"villainWinPct"`
+	TiePct        float64 `json:"tiePct"`
+	Combos        int     `json:"combos"` // villain combos not blocked by known cards
+}
+
+func handleRangeEquityExact(w http.ResponseWriter, r *http.Request) {
+	var req rangeEquityExactRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, riverOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	villainRange, err := parseVillainRange(req.VillainRange, req.VillainRangePct)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	res := poker.ExactRangeEquity(hole, community, villainRange)
+	if res.TrialsRun == 0 {
+		http.Error(w, "every villain combo conflicts with known cards", http.StatusBadRequest)
+		return
+	}
+
+	heroWin, villainWin, tie := res.Rates()
+	writeJSON(w, rangeEquityExactResponse{
+		HeroWinPct:    heroWin * 100.0,
+		VillainWinPct: villainWin * 100.0,
+		TiePct:        tie * 100.0,
+		Combos:        res.TrialsRun,
+	})
+}
+
+// parseVillainRange builds a range from explicit 2-card combos plus the
+// top pct percent of starting hands, each combo counted once. At least one
+// must be given.
+func parseVillainRange(combos [][]string, pct float64) ([][2]poker.Card, error) {
+	if pct < 0 || pct > 100 {
+		return nil, fmt.Errorf("villainRangePct must be between 0 and 100")
+	}
+	if len(combos) == 0 && pct == 0 {
+		return nil, fmt.Errorf("require villainRange or villainRangePct")
+	}
+
+	out := poker.TopPercentRange(pct)
+	for i, combo := range combos {
+		if len(combo) != 2 {
+			return nil, fmt.Errorf("villain combo %d must have 2 cards", i+1)
+		}
+		cards, err := parseCards(combo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid villain combo %d: %v", i+1, err)
+		}
+		out = append(out, [2]poker.Card{cards[0], cards[1]})
+	}
+	return poker.DedupeCombos(out), nil
+}
+
+type rangeVsRangeRequest struct {
+	HeroRange    string   `json:"heroRange"`    // weighted notation, e.g. "AKs, QQ:0.5"
+	VillainRange string   `json:"villainRange"` // same notation
+	Community    []string `json:"community"`    // 0, 3, 4, 5
+	Trials       int      `json:"trials"`
+}
+
+type rangeVsRangeResponse struct {
+	HeroEquityPct float64 `json:"heroEquityPct"` // ties count half
+}
+
+func handleRangeVsRange(w http.ResponseWriter, r *http.Request) {
+	var req rangeVsRangeRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.Trials <= 0 || req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be between 1 and %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+
+	community, err := parseBoard(req.Community, anyStreet)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(community); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	heroRange, err := poker.ParseWeightedRange(req.HeroRange)
+	if err != nil {
+		http.Error(w, "invalid heroRange: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	villainRange, err := poker.ParseWeightedRange(req.VillainRange)
+	if err != nil {
+		http.Error(w, "invalid villainRange: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if poker.RangeComboCount(heroRange.Combos, community) == 0 || poker.RangeComboCount(villainRange.Combos, community) == 0 {
+		http.Error(w, "every combo of a range conflicts with the board", http.StatusBadRequest)
+		return
+	}
+
+	equity := poker.WeightedRangeVsRangeEquity(heroRange, villainRange, community, req.Trials)
+	writeJSON(w, rangeVsRangeResponse{HeroEquityPct: equity * 100.0})
+}
+
+type handVsRangeRequest struct {
+	Hole            []string   `json:"hole"`      // hero hole (2)
+	Community       []string   `json:"community"` // 3, 4, 5
+	VillainRange    [][]string `json:"villainRange"`
+	VillainRangePct float64    `json:"villainRangePct"`
+}
+
+type handVsRangeResponse struct {
+	PercentilePct float64 `json:"percentilePct"` // % of villain's range hero beats now, ties half
+}
+
+func handleHandVsRange(w http.ResponseWriter, r *http.Request) {
+	var req handVsRangeRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, postflop)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	villainRange, err := parseVillainRange(req.VillainRange, req.VillainRangePct)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, handVsRangeResponse{
+		PercentilePct: poker.HandPercentileVsRange(hole, community, villainRange) * 100.0,
+	})
+}
+
+type wawbResponse struct {
+	Classification string `json:"classification"` // "way ahead/way behind" or "marginal"
+}
+
+// handleWAWB takes the same request as /hand-vs-range.
+func handleWAWB(w http.ResponseWriter, r *http.Request) {
+	var req handVsRangeRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, postflop)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	villainRange, err := parseVillainRange(req.VillainRange, req.VillainRangePct)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, wawbResponse{
+		Classification: poker.WayAheadWayBehind(hole, community, villainRange),
+	})
+}
+
+type cleanOutsRequest struct {
+	Hole      []string `json:"hole"`      // hero hole (2)
+	Community []string `json:"community"` // 3 or 4 cards
+
+	// Villain's range as for hand-vs-range; omit both for any two cards.
+	VillainRange    [][]string `json:"villainRange"`
+	VillainRangePct float64    `json:"villainRangePct"`
+}
+
+type cleanOutsResponse struct {
+	Outs      []string `json:"outs"`      // next cards that improve hero's hand category
+	CleanOuts []string `json:"cleanOuts"` // outs after which no villain combo beats hero
+	DirtyOuts []string `json:"dirtyOuts"` // outs that also give some villain combo a better hand
+}
+
+func handleCleanOuts(w http.ResponseWriter, r *http.Request) {
+	var req cleanOutsRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, flopOrTurn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var villainRange [][2]poker.Card
+	if len(req.VillainRange) > 0 || req.VillainRangePct != 0 {
+		villainRange, err = parseVillainRange(req.VillainRange, req.VillainRangePct)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	clean, dirty := poker.CleanOuts(hole, community, villainRange)
+	writeJSON(w, cleanOutsResponse{
+		Outs:      cardsToStrings(poker.Outs(hole, community)),
+		CleanOuts: cardsToStrings(clean),
+		DirtyOuts: cardsToStrings(dirty),
+	})
+}
+
+type payoutRequest struct {
+	Players       [][]string `json:"players"`       // 2-9 seats, 2 hole cards each; null or [] if folded
+	Community     []string   `json:"community"`     // 0, 3, 4, 5
+	Contributions []int      `json:"contributions"` // chips each seat put in
+	Trials        int        `json:"trials"`        // preflop only
+	Seed          int64      `json:"seed"`          // preflop only; zero picks a fresh seed
+	Button        int        `json:"button"`        // 1-based button seat for odd chips; 0 splits exactly
+}
+
+type potPayout struct {
+	Amount        int       `json:"amount"`
+	EligibleSeats []int     `json:"eligibleSeats"` // 1-based, live seats only
+	Shares        []float64 `json:"shares"`        // expected chips per seat
+}
+
+type payoutResponse struct {
+	Pots   []potPayout `json:"pots"`   // main pot first
+	Totals []float64   `json:"totals"` // expected chips per seat over all pots
+}
+
+func handlePayout(w http.ResponseWriter, r *http.Request) {
+	var req payoutRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if len(req.Players) < 2 || len(req.Players) > 9 {
+		http.Error(w, "require between 2 and 9 players", http.StatusBadRequest)
+		return
+	}
+	if len(req.Contributions) != len(req.Players) {
+		http.Error(w, "require one contribution per player", http.StatusBadRequest)
+		return
+	}
+	if len(req.Community) == 0 && req.Trials <= 0 {
+		http.Error(w, "trials must be > 0 preflop", http.StatusBadRequest)
+		return
+	}
+	if req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be at most %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+	if req.Button < 0 || req.Button > len(req.Players) {
+		http.Error(w, fmt.Sprintf("button must be between 1 and %d", len(req.Players)), http.StatusBadRequest)
+		return
+	}
+
+	community, err := parseBoard(req.Community, anyStreet)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	holes, err := parseSeats(req.Players)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(append(slices.Concat(holes...), community...)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	maxLive := 0
+	for i, h := range holes {
+		if req.Contributions[i] < 0 {
+			http.Error(w, fmt.Sprintf("seat %d contribution must be >= 0", i+1), http.StatusBadRequest)
+			return
+		}
+		if len(h) > 0 {
+			maxLive = max(maxLive, req.Contributions[i])
+		}
+	}
+	if maxLive == 0 {
+		http.Error(w, "at least one seat that has not folded must contribute", http.StatusBadRequest)
+		return
+	}
+
+	resp := payoutResponse{Totals: make([]float64, len(req.Players))}
+	// Button 0 (unset) becomes -1: split tied pots exactly.
+	for _, pp := range poker.ExpectedPayoutsWithButton(holes, community, req.Contributions, req.Trials, req.Seed, req.Button-1) {
+		seats := make([]int, len(pp.Eligible))
+		for i, p := range pp.Eligible {
+			seats[i] = p + 1
+		}
+		for p, share := range pp.Shares {
+			resp.Totals[p] += share
+		}
+		resp.Pots = append(resp.Pots, potPayout{Amount: pp.Amount, EligibleSeats: seats, Shares: pp.Shares})
+	}
+
+	writeJSON(w, resp)
+}
+
+type featuresRequest struct {
+	Hole      []string `json:"hole"`      // hero hole (2)
+	Community []string `json:"community"` // 3, 4, 5
+}
+
+type featuresResponse struct {
+	Features map[string]float64 `json:"features"`
+}
+
+func handleFeatures(w http.ResponseWriter, r *http.Request) {
+	var req featuresRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, postflop)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	features, err := poker.HandFeatures(hole, community)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, featuresResponse{Features: features})
+}
+
+type runItMultipleRequest struct {
+	Hole        []string `json:"hole"`        // hero hole (2)
+	VillainHole []string `json:"villainHole"` // villain hole (2)
+	Community   []string `json:"community"`   // 0, 3, 4, 5
+	Runs        int      `json:"runs"`        // e.g. 2 or 3
+	Seed        int64    `json:"seed"`        // zero picks a fresh seed
+}
+
+type boardRun struct {
+	Board  []string `json:"board"`
+	Winner string   `json:"winner"` // "hero", "villain" or "tie"
+}
+
+type runItMultipleResponse struct {
+	Runs          []boardRun `json:"runs"`
+	HeroPotPct    float64    `json:"heroPotPct"` // % of the pot hero wins over all runs
+	VillainPotPct float64    `json:"villainPotPct"`
+	SeedUsed      int64      `json:"seedUsed"`
+}
+
+func handleRunItMultiple(w http.ResponseWriter, r *http.Request) {
+	var req runItMultipleRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, anyStreet)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	villainHole, err := parseHole("villain hole", req.VillainHole)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(hole, villainHole, community); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	res, err := poker.RunItMultiple(hole, villainHole, community, req.Runs, req.Seed)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := runItMultipleResponse{
+		HeroPotPct:    res.HeroShare * 100.0,
+		VillainPotPct: res.VillainShare * 100.0,
+		SeedUsed:      res.Seed,
+	}
+	for _, run := range res.Runs {
+		winner := "tie"
+		switch run.Result {
+		case 1:
+			winner = "hero"
+		case -1:
+			winner = "villain"
+		}
+		resp.Runs = append(resp.Runs, boardRun{Board: cardsToStrings(run.Board), Winner: winner})
+	}
+	writeJSON(w, resp)
+}
+
+type cardImpactRequest struct {
+	Hole        []string `json:"hole"`        // hero hole (2)
+	VillainHole []string `json:"villainHole"` // villain hole (2)
+	Community   []string `json:"community"`   // 3 or 4
+}
+
+type cardImpactResponse struct {
+	Leader       string   `json:"leader"` // "hero", "villain" or "tie" on the current board
+	Helps        []string `json:"helps"`  // next cards that improve hero's standing
+	Hurts        []string `json:"hurts"`  // next cards that worsen it
+	Neutral      []string `json:"neutral"`
+	HelpsCount   int      `json:"helpsCount"`
+	HurtsCount   int      `json:"hurtsCount"`
+	NeutralCount int      `json:"neutralCount"`
+}
+
+func handleCardImpact(w http.ResponseWriter, r *http.Request) {
+	var req cardImpactRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, flopOrTurn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	villainHole, err := parseHole("villain hole", req.VillainHole)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(hole, villainHole, community); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	helps, hurts, neutral := poker.CardImpact(hole, villainHole, community)
+	resp := cardImpactResponse{
+		Leader:       "tie",
+		Helps:        cardsToStrings(helps),
+		Hurts:        cardsToStrings(hurts),
+		Neutral:      cardsToStrings(neutral),
+		HelpsCount:   len(helps),
+		HurtsCount:   len(hurts),
+		NeutralCount: len(neutral),
+	}
+	heroNow := poker.EvaluateBestHand(append(append([]poker.Card{}, hole...), community...))
+	villainNow := poker.EvaluateBestHand(append(append([]poker.Card{}, villainHole...), community...))
+	switch cmp := poker.CompareHandValues(heroNow, villainNow); {
+	case cmp > 0:
+		resp.Leader = "hero"
+	case cmp < 0:
+		resp.Leader = "villain"
+	}
+	writeJSON(w, resp)
+}
+
+// stateTrials is the number of random boards evaluate-state deals for a
+// preflop game state.
+const stateTrials = 10000
+
+type stateSeat struct {
+	Seat          int      `json:"seat"` // 1-based
+	Hole          []string `json:"hole"`
+	Folded        bool     `json:"folded"`
+	EquityPct     float64  `json:"equityPct"`               // share of an evenly contested pot
+	ExpectedChips *float64 `json:"expectedChips,omitempty"` // with contributions
+	Category      string   `json:"category,omitempty"`      // once the flop is out
+	Description   string   `json:"description,omitempty"`
+}
+
+type evaluateStateResponse struct {
+	Seats   []stateSeat `json:"seats"`
+	Pot     int         `json:"pot"`
+	Ranking [][]int     `json:"ranking,omitempty"` // showdown tie groups on a complete board
+}
+
+// handleEvaluateState evaluates a game state in the format of
+// poker.SaveGameState, posted as the request body.
+func handleEvaluateState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	state, err := poker.LoadGameState(r.Body)
+	if err != nil {
+		http.Error(w, "invalid game state: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	equity := potShares(state.Players, state.Board, stateTrials, 0)
+	var chips []float64
+	if len(state.Contributions) > 0 && state.Pot > 0 {
+		chips = make([]float64, len(state.Players))
+		for _, pp := range poker.ExpectedPayouts(state.Players, state.Board, state.Contributions, stateTrials, 0) {
+			for i, share := range pp.Shares {
+				chips[i] += share
+			}
+		}
+	}
+
+	resp := evaluateStateResponse{Pot: state.Pot}
+	for i, h := range state.Players {
+		seat := stateSeat{Seat: i + 1, Hole: cardsToStrings(h), Folded: len(h) == 0, EquityPct: equity[i] * 100.0}
+		if chips != nil {
+			seat.ExpectedChips = &chips[i]
+		}
+		if len(h) > 0 && len(state.Board) >= 3 {
+			hv := poker.EvaluateBestHand(append(append([]poker.Card{}, h...), state.Board...))
+			seat.Category = poker.CategoryName(hv.Category)
+			seat.Description = poker.DescribeHand(hv)
+		}
+		resp.Seats = append(resp.Seats, seat)
+	}
+	if len(state.Board) == 5 {
+		for _, g := range poker.RankHands(state.Players, state.Board) {
+			resp.Ranking = append(resp.Ranking, oneBased(g))
+		}
+	}
+	writeJSON(w, resp)
+}
+
+// potShares returns the share (0-1) of a pot every live seat contests
+// equally that each seat wins on average. Boards of 3 or more cards are
+// enumerated exactly; preflop, trials boards are dealt from seed.
+func potShares(holes [][]poker.Card, board []poker.Card, trials int, seed int64) []float64 {
+	even := make([]int, len(holes))
+	for i, h := range holes {
+		if len(h) > 0 {
+			even[i] = 1
+		}
+	}
+	shares := make([]float64, len(holes))
+	for _, pp := range poker.ExpectedPayouts(holes, board, even, trials, seed) {
+		for i, share := range pp.Shares {
+			shares[i] += share / float64(pp.Amount)
+		}
+	}
+	return shares
+}
+
+type equityRequest struct {
+	Players   [][]string `json:"players"`   // 2-9 seats, 2 hole cards each; null or [] if folded
+	Community []string   `json:"community"` // exactly 5
+}
+
+type equityPlayer struct {
+	Seat     int     `json:"seat"` // 1-based
+	Win      bool    `json:"win"`  // wins or shares the pot
+	Tie      bool    `json:"tie"`  // shares the pot
+	SharePct float64 `json:"sharePct"`
+}
+
+type equityResponse struct {
+	Players []equityPlayer `json:"players"`
+}
+
+func handleEquity(w http.ResponseWriter, r *http.Request) {
+	var req equityRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if len(req.Players) < 2 || len(req.Players) > 9 {
+		http.Error(w, "require between 2 and 9 players", http.StatusBadRequest)
+		return
+	}
+	community, err := parseBoard(req.Community, riverOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	holes, err := parseSeats(req.Players)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(append(slices.Concat(holes...), community...)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var resp equityResponse
+	for _, pe := range poker.EvaluateMultiway(holes, community) {
+		resp.Players = append(resp.Players, equityPlayer{
+			Seat:     pe.Player + 1,
+			Win:      pe.Win,
+			Tie:      pe.Tie,
+			SharePct: pe.Share * 100.0,
+		})
+	}
+	writeJSON(w, resp)
+}
+
+type showdownFullRequest struct {
+	Players   [][]string `json:"players"`   // 2-9 seats, 2 hole cards each; null or [] if folded
+	Community []string   `json:"community"` // 0, 3, 4, 5
+	Trials    int        `json:"trials"`    // preflop only; default stateTrials
+	Seed      int64      `json:"seed"`      // preflop only; zero picks a fresh seed
+}
+
+type showdownSeat struct {
+	Seat        int      `json:"seat"` // 1-based
+	Folded      bool     `json:"folded"`
+	Category    string   `json:"category,omitempty"`    // once the flop is out
+	Description string   `json:"description,omitempty"` // once the flop is out
+	BestFive    []string `json:"bestFive,omitempty"`    // on a complete board
+	PotSharePct float64  `json:"potSharePct"`
+}
+
+type showdownFullResponse struct {
+	Seats   []showdownSeat `json:"seats"`
+	Winners []int          `json:"winners,omitempty"` // on a complete board; several on a chop
+	Method  string         `json:"method"`            // "exact" or "monte_carlo"
+}
+
+func handleShowdownFull(w http.ResponseWriter, r *http.Request) {
+	var req showdownFullRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if len(req.Players) < 2 || len(req.Players) > 9 {
+		http.Error(w, "require between 2 and 9 players", http.StatusBadRequest)
+		return
+	}
+	if req.Trials < 0 || req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be between 0 and %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+	trials := req.Trials
+	if trials == 0 {
+		trials = stateTrials
+	}
+
+	community, err := parseBoard(req.Community, anyStreet)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	holes, err := parseSeats(req.Players)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(append(slices.Concat(holes...), community...)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	shares := potShares(holes, community, trials, req.Seed)
+	resp := showdownFullResponse{Method: poker.MethodExact}
+	if len(community) == 0 {
+		resp.Method = poker.MethodMonteCarlo
+	}
+	for i, h := range holes {
+		seat := showdownSeat{Seat: i + 1, Folded: len(h) == 0, PotSharePct: shares[i] * 100.0}
+		if len(h) > 0 && len(community) >= 3 {
+			cards := append(append([]poker.Card{}, h...), community...)
+			hv := poker.EvaluateBestHand(cards)
+			seat.Category = poker.CategoryName(hv.Category)
+			seat.Description = poker.DescribeHand(hv)
+			if len(community) == 5 {
+				_, used, _ := poker.SplitBestHand(cards)
+				seat.BestFive = cardsToStrings(used)
+			}
+		}
+		resp.Seats = append(resp.Seats, seat)
+	}
+	if len(community) == 5 {
+		resp.Winners = oneBased(poker.RankHands(holes, community)[0])
+	}
+	writeJSON(w, resp)
+}
+
+type mdfRequest struct {
+	Pot float64 `json:"pot"` // pot before the bet
+	Bet float64 `json:"bet"`
+}
+
+type mdfResponse struct {
+	MDFPct   float64 `json:"mdfPct"`   // % of hands to continue with
+	BluffPct float64 `json:"bluffPct"` // % of the betting range that can be bluffs
+}
+
+func handleMDF(w http.ResponseWriter, r *http.Request) {
+	var req mdfRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.Pot < 0 || req.Bet < 0 {
+		http.Error(w, "pot and bet must be >= 0", http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, mdfResponse{
+		MDFPct:   poker.MinDefenseFrequency(req.Pot, req.Bet) * 100.0,
+		BluffPct: poker.IndifferenceBluffFrequency(req.Pot, req.Bet) * 100.0,
+	})
+}
+
+type betCandidate struct {
+	Size    float64 `json:"size"`
+	FoldPct float64 `json:"foldPct"` // % of the time villain folds to this size
+}
+
+type bestBetRequest struct {
+	Pot        float64        `json:"pot"` // pot before hero bets
+	Candidates []betCandidate `json:"candidates"`
+
+	// Hero's equity when called, either given directly as EquityPct or
+	// simulated from Hole, Community, NumOpponents and Trials when Hole is
+	// set.
+	EquityPct    float64  `json:"equityPct"`
+	Hole         []string `json:"hole"`
+	Community    []string `json:"community"`
+	NumOpponents int      `json:"numOpponents"`
+	Trials       int      `json:"trials"`
+}
+
+type betEV struct {
+	Size    float64 `json:"size"`
+	FoldPct float64 `json:"foldPct"`
+	EV      float64 `json:"ev"`
+}
+
+type bestBetResponse struct {
+	Best      betEV   `json:"best"`
+	All       []betEV `json:"all"` // every candidate, in request order
+	EquityPct float64 `json:"equityPct"`
+}
+
+func handleBestBet(w http.ResponseWriter, r *http.Request) {
+	var req bestBetRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.Pot < 0 {
+		http.Error(w, "pot must be >= 0", http.StatusBadRequest)
+		return
+	}
+	if len(req.Candidates) == 0 {
+		http.Error(w, "at least one candidate is required", http.StatusBadRequest)
+		return
+	}
+	candidates := make([]poker.BetCandidate, len(req.Candidates))
+	for i, c := range req.Candidates {
+		if c.Size < 0 || c.FoldPct < 0 || c.FoldPct > 100 {
+			http.Error(w, "candidate sizes must be >= 0 and foldPct between 0 and 100", http.StatusBadRequest)
+			return
+		}
+		candidates[i] = poker.BetCandidate{Size: c.Size, FoldProbability: c.FoldPct / 100.0}
+	}
+
+	equity := req.EquityPct / 100.0
+	if len(req.Hole) > 0 {
+		hole, community, opts, err := parseSimulation(simulateRequest{
+			Hole:         req.Hole,
+			Community:    req.Community,
+			NumOpponents: req.NumOpponents,
+			Trials:       req.Trials,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		heroWin, _, tie := poker.SimulateEquityWithOptions(hole, community, req.NumOpponents, req.Trials, opts).Rates()
+		equity = heroWin + tie/2
+	} else if req.EquityPct < 0 || req.EquityPct > 100 {
+		http.Error(w, "equityPct must be between 0 and 100", http.StatusBadRequest)
+		return
+	}
+
+	best := poker.BestBetSize(req.Pot, equity, candidates)
+	resp := bestBetResponse{
+		Best:      betEV{Size: best.Size, FoldPct: best.FoldProbability * 100.0, EV: best.EV},
+		All:       make([]betEV, len(candidates)),
+		EquityPct: equity * 100.0,
+	}
+	for i, c := range candidates {
+		resp.All[i] = betEV{Size: c.Size, FoldPct: c.FoldProbability * 100.0, EV: poker.BetEV(req.Pot, equity, c.Size, c.FoldProbability)}
+	}
+	writeJSON(w, resp)
+}
+
+type importHandRequest struct {
+	Text string `json:"text"` // PokerStars-style hand history
+}
+
+type importHandResponse struct {
+	Hole         []string `json:"hole"`
+	Board        []string `json:"board"`
+	StartingHand string   `json:"startingHand"` // e.g. "AKs"
+
+	// Hero's best hand so far; only once the flop is out.
+	Category    string   `json:"category,omitempty"`
+	Kickers     []string `json:"kickers,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+func handleImportHand(w http.ResponseWriter, r *http.Request) {
+	var req importHandRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, board, err := poker.ParsePokerStarsHand(req.Text)
+	if err != nil {
+		http.Error(w, "invalid hand history: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := importHandResponse{
+		Hole:         cardsToStrings(hole),
+		Board:        cardsToStrings(board),
+		StartingHand: poker.StartingHandName(hole),
+	}
+	if len(board) >= 3 {
+		hv := poker.EvaluateBestHand(append(append([]poker.Card{}, hole...), board...))
+		resp.Category = poker.CategoryName(hv.Category)
+		resp.Kickers = ranksToStrings(hv.Kickers)
+		resp.Description = poker.DescribeHand(hv)
+	}
+
+	writeJSON(w, resp)
+}
+
+type currentBestOddsRequest struct {
+	Hole      []string `json:"hole"`      // hero hole (2)
+	Community []string `json:"community"` // 3, 4, 5
+}
+
+type currentBestOddsResponse struct {
+	AheadPct  float64 `json:"aheadPct"` // % of opponent holdings hero beats right now
+	TiedPct   float64 `json:"tiedPct"`
+	BehindPct float64 `json:"behindPct"`
+	Holdings  int     `json:"holdings"` // opponent holdings enumerated
+}
+
+func handleCurrentBestOdds(w http.ResponseWriter, r *http.Request) {
+	var req currentBestOddsRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, postflop)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	known := append(append([]poker.Card{}, hole...), community...)
+
+	ahead, tied, behind := poker.CurrentStanding(hole, community, poker.RemainingHoldings(known))
+	n := float64(ahead + tied + behind)
+	writeJSON(w, currentBestOddsResponse{
+		AheadPct:  float64(ahead) / n * 100.0,
+		TiedPct:   float64(tied) / n * 100.0,
+		BehindPct: float64(behind) / n * 100.0,
+		Holdings:  ahead + tied + behind,
+	})
+}
+
+type equitySourcesRequest struct {
+	Hole         []string `json:"hole"`         // hero hole (2)
+	Community    []string `json:"community"`    // 3, 4, 5
+	NumOpponents int      `json:"numOpponents"` // >= 1
+	Trials       int      `json:"trials"`
+	Seed         int64    `json:"seed"` // zero picks a fresh seed
+}
+
+type equitySourcesResponse struct {
+	HeroWinPct     float64 `json:"heroWinPct"`
+	AheadWinPct    float64 `json:"aheadWinPct"`    // % of trials won while already ahead now
+	ImprovedWinPct float64 `json:"improvedWinPct"` // % of trials won by improving later
+	AheadShare     float64 `json:"aheadShare"`     // fraction (0-1) of hero's wins from being ahead
+	TrialsRun      int     `json:"trialsRun"`
+	SeedUsed       int64   `json:"seedUsed"`
+}
+
+func handleEquitySources(w http.ResponseWriter, r *http.Request) {
+	var req equitySourcesRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.NumOpponents < 1 || req.NumOpponents > poker.Holdem.MaxOpponents() {
+		http.Error(w, fmt.Sprintf("numOpponents must be between 1 and %d", poker.Holdem.MaxOpponents()), http.StatusBadRequest)
+		return
+	}
+	if req.Trials <= 0 || req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be between 1 and %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, postflop)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	res := poker.SimulateEquityWithOptions(hole, community, req.NumOpponents, req.Trials, poker.SimulationOptions{
+		TrackSources: true,
+		Seed:         req.Seed,
+	})
+	n := float64(res.TrialsRun)
+	resp := equitySourcesResponse{
+		HeroWinPct:     float64(res.HeroWins) / n * 100.0,
+		AheadWinPct:    float64(res.WinsAhead) / n * 100.0,
+		ImprovedWinPct: float64(res.HeroWins-res.WinsAhead) / n * 100.0,
+		TrialsRun:      res.TrialsRun,
+		SeedUsed:       res.Seed,
+	}
+	if res.HeroWins > 0 {
+		resp.AheadShare = float64(res.WinsAhead) / float64(res.HeroWins)
+	}
+	writeJSON(w, resp)
+}
+
+type equityComparisonRequest struct {
+	Hole      []string `json:"hole"`      // hero hole (2)
+	Community []string `json:"community"` // 0, 3, 4, 5
+	Trials    int      `json:"trials"`
+	Seed      int64    `json:"seed"` // zero picks a fresh seed
+}
+
+type equityComparisonResponse struct {
+	RandomEquityPct     float64 `json:"randomEquityPct"`     // vs any two cards
+	ReasonableEquityPct float64 `json:"reasonableEquityPct"` // vs the top reasonableRangePct% of hands
+	DifferencePct       float64 `json:"differencePct"`       // reasonable minus random
+	ReasonableRangePct  float64 `json:"reasonableRangePct"`
+}
+
+func handleEquityComparison(w http.ResponseWriter, r *http.Request) {
+	var req equityComparisonRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.Trials <= 0 || req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be between 1 and %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, anyStreet)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	random, reasonable := poker.RangeAssumptionEquity(hole, community, req.Trials, req.Seed)
+	writeJSON(w, equityComparisonResponse{
+		RandomEquityPct:     random * 100.0,
+		ReasonableEquityPct: reasonable * 100.0,
+		DifferencePct:       (reasonable - random) * 100.0,
+		ReasonableRangePct:  poker.ReasonableRangePct,
+	})
+}
+
+type playHandRequest struct {
+	Players int   `json:"players"` // 2-10 bots
+	Seed    int64 `json:"seed"`    // replays a previous hand; zero deals a fresh one
+}
+
+type botStep struct {
+	Street    string  `json:"street"` // "preflop", "flop", "turn", "river"
+	Seat      int     `json:"seat"`   // 1-based
+	EquityPct float64 `json:"equityPct"`
+	Action    string  `json:"action"` // "fold", "check" or "call"
+}
+
+type playHandResponse struct {
+	Seats    [][]string `json:"seats"` // every seat's hole cards, seat 1 first
+	Board    []string   `json:"board"` // cards dealt before the hand ended
+	Actions  []botStep  `json:"actions"`
+	Folded   []int      `json:"folded,omitempty"`
+	Winners  []int      `json:"winners"`
+	Ranking  [][]int    `json:"ranking,omitempty"` // showdown tie groups, best first
+	SeedUsed int64      `json:"seedUsed"`
+}
+
+func handlePlayHand(w http.ResponseWriter, r *http.Request) {
+	var req playHandRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if maxPlayers := poker.Holdem.MaxOpponents() + 1; req.Players < 2 || req.Players > maxPlayers {
+		http.Error(w, fmt.Sprintf("players must be between 2 and %d", maxPlayers), http.StatusBadRequest)
+		return
+	}
+
+	hand := poker.PlayHand(req.Players, req.Seed)
+	resp := playHandResponse{
+		Board:    cardsToStrings(hand.Board),
+		Actions:  make([]botStep, len(hand.Actions)),
+		Folded:   oneBased(hand.Folded),
+		Winners:  oneBased(hand.Winners),
+		SeedUsed: hand.Seed,
+	}
+	for _, h := range hand.Holes {
+		resp.Seats = append(resp.Seats, cardsToStrings(h))
+	}
+	for i, a := range hand.Actions {
+		resp.Actions[i] = botStep{Street: a.Street, Seat: a.Seat + 1, EquityPct: a.Equity * 100.0, Action: a.Action}
+	}
+	for _, group := range hand.Ranking {
+		resp.Ranking = append(resp.Ranking, oneBased(group))
+	}
+	writeJSON(w, resp)
+}
+
+// oneBased converts 0-based seat indexes to the 1-based seats used in
+// responses.
+func oneBased(seats []int) []int {
+	if seats == nil {
+		return nil
+	}
+	out := make([]int, len(seats))
+	for i, s := range seats {
+		out[i] = s + 1
+	}
+	return out
+}
+
+// maxBatchHands bounds the size of a single batch evaluate request.
+const maxBatchHands = 10000
+
+type batchHand struct {
+	Hole      []string `json:"hole"`      // 2 cards
+	Community []string `json:"community"` // 5 cards
+}
+
+type batchEvaluateRequest struct {
+	Hands  []batchHand `json:"hands"`
+	Sort   string      `json:"sort"`   // "" keeps request order, "strength" ranks strongest first
+	Offset int         `json:"offset"` // first result to return
+	Limit  int         `json:"limit"`  // max results to return; 0 = all
+}
+
+type batchResult struct {
+	Index    int      `json:"index"` // position in the request
+	Category string   `json:"category"`
+	Kickers  []string `json:"kickers"`
+	Score    int      `json:"score"`
+}
+
+type batchEvaluateResponse struct {
+	Total   int           `json:"total"`
+	Results []batchResult `json:"results"`
+}
+
+func handleEvaluateBatch(w http.ResponseWriter, r *http.Request) {
+	var req batchEvaluateRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if len(req.Hands) == 0 || len(req.Hands) > maxBatchHands {
+		http.Error(w, fmt.Sprintf("require between 1 and %d hands", maxBatchHands), http.StatusBadRequest)
+		return
+	}
+	if req.Sort != "" && req.Sort != "strength" {
+		http.Error(w, "sort must be empty or \"strength\"", http.StatusBadRequest)
+		return
+	}
+	if req.Offset < 0 || req.Limit < 0 {
+		http.Error(w, "offset and limit must be >= 0", http.StatusBadRequest)
+		return
+	}
+
+	results := make([]batchResult, len(req.Hands))
+	for i, h := range req.Hands {
+		hole, community, err := decodeHand(h.Hole, h.Community, riverOnly)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("hand %d: %v", i, err), http.StatusBadRequest)
+			return
+		}
+		hv := poker.EvaluateBestHand(append(hole, community...))
+		results[i] = batchResult{
+			Index:    i,
+			Category: poker.CategoryName(hv.Category),
+			Kickers:  ranksToStrings(hv.Kickers),
+			Score:    hv.Score(),
+		}
+	}
+
+	if req.Sort == "strength" {
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Score > results[j].Score
+		})
+	}
+
+	start := req.Offset
+	if start > len(results) {
+		start = len(results)
+	}
+	end := len(results)
+	if req.Limit > 0 && start+req.Limit < end {
+		end = start + req.Limit
+	}
+
+	writeJSON(w, batchEvaluateResponse{
+		Total:   len(results),
+		Results: results[start:end],
+	})
+}
+
+type boardTextureRequest struct {
+	Community []string `json:"community"` // 3 or 4 cards
+}
+
+type boardTextureResponse struct {
+	PairedPct        float64  `json:"pairedPct"`
+	FlushPossiblePct float64  `json:"flushPossiblePct"`
+	FourStraightPct  float64  `json:"fourStraightPct"`
+	PossibleHands    []string `json:"possibleHands"` // categories some holding makes now
+	Wetness          float64  `json:"wetness"`       // 0 (dry) to 1 (wet), see poker.BoardWetness
+}
+
+func handleBoardTexture(w http.ResponseWriter, r *http.Request) {
+	var req boardTextureRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	community, err := parseBoard(req.Community, flopOrTurn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(community); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t := poker.BoardTextureOdds(community)
+	writeJSON(w, boardTextureResponse{
+		PairedPct:        t.Paired * 100.0,
+		FlushPossiblePct: t.FlushPossible * 100.0,
+		FourStraightPct:  t.FourStraight * 100.0,
+		PossibleHands:    categoriesToStrings(poker.PossibleMadeHands(community)),
+		Wetness:          poker.BoardWetness(community),
+	})
+}
+
+// decodePost decodes a POSTed JSON body into v. For any other method it
+// writes a 405, for a malformed body a 400, and returns false.
+func decodePost(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// Board sizes a request's community cards may have.
+var (
+	anyStreet  = []int{0, 3, 4, 5}
+	postflop   = []int{3, 4, 5}
+	flopOrTurn = []int{3, 4}
+	riverOnly  = []int{5}
+)
+
+// decodeHand parses hero's two hole cards and a board of one of sizes, and
+// checks that no card repeats. Errors are 400 messages.
+func decodeHand(hole, community []string, sizes []int) (holeCards, board []poker.Card, err error) {
+	if holeCards, err = parseHole("hero hole", hole); err != nil {
+		return nil, nil, err
+	}
+	if board, err = parseBoard(community, sizes); err != nil {
+		return nil, nil, err
+	}
+	if err = checkDistinct(holeCards, board); err != nil {
+		return nil, nil, err
+	}
+	return holeCards, board, nil
+}
+
+// parseHole parses a two-card hole, naming it in errors ("hero hole").
+func parseHole(name string, strs []string) ([]poker.Card, error) {
+	if len(strs) != 2 {
+		return nil, fmt.Errorf("%s must be 2 cards", name)
+	}
+	cards, err := parseCards(strs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return cards, nil
+}
+
+// parseBoard parses community cards, which must number one of sizes.
+func parseBoard(strs []string, sizes []int) ([]poker.Card, error) {
+	if !slices.Contains(sizes, len(strs)) {
+		names := make([]string, len(sizes))
+		for i, n := range sizes {
+			names[i] = strconv.Itoa(n)
+		}
+		if len(names) > 2 {
+			names[len(names)-1] = "or " + names[len(names)-1]
+			return nil, fmt.Errorf("community must be %s cards", strings.Join(names, ", "))
+		}
+		return nil, fmt.Errorf("community must be %s cards", strings.Join(names, " or "))
+	}
+	cards, err := parseCards(strs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid community: %v", err)
+	}
+	return cards, nil
+}
+
+// parseSeats parses one two-card hole per seat, leaving folded seats (null
+// or []) empty. At least one seat must still be in the hand.
+func parseSeats(players [][]string) ([][]poker.Card, error) {
+	holes := make([][]poker.Card, len(players))
+	live := 0
+	for i, p := range players {
+		if len(p) == 0 {
+			continue
+		}
+		hole, err := parseHole(fmt.Sprintf("seat %d hole", i+1), p)
+		if err != nil {
+			return nil, err
+		}
+		holes[i] = hole
+		live++
+	}
+	if live == 0 {
+		return nil, errors.New("at least one seat must not have folded")
+	}
+	return holes, nil
+}
+
+// checkDistinct reports "duplicate cards" if any card appears twice across
+// groups.
+func checkDistinct(groups ...[]poker.Card) error {
+	var all []poker.Card
+	for _, g := range groups {
+		all = append(all, g...)
+	}
+	if poker.HasDuplicates(all) {
+		return errors.New("duplicate cards")
+	}
+	return nil
+}
+
+func parseCards(strs []string) ([]poker.Card, error) {
+	cs := make([]poker.Card, 0, len(strs))
+	for _, s := range strs {
+		c, err := poker.ParseCard(s)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func categoriesToStrings(cats []int) []string {
+	out := make([]string, len(cats))
+	for i, c := range cats {
+		out[i] = poker.CategoryName(c)
+	}
+	return out
+}
+
+func cardsToStrings(cs []poker.Card) []string {
+	out := make([]string, len(cs))
+	for i, c := range cs {
+		out[i] = c.Str
+	}
+	return out
+}
+
+func ranksToStrings(rs []poker.Rank) []string {
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = r.String()
+	}
+	return out
+}
+
+type drawsRequest struct {
+	Hole      []string `json:"hole"`      // hero hole (2)
+	Community []string `json:"community"` // 3 or 4
+}
+
+type drawsResponse struct {
+	StraightDraw  string `json:"straightDraw"`  // none, gutshot, double gutshot, open-ended or straight
+	StraightOuts  int    `json:"straightOuts"`  // 4 per completing rank
+	FlushDrawOuts int    `json:"flushDrawOuts"` // unseen cards of hero's flush draw suit
+}
+
+func handleDraws(w http.ResponseWriter, r *http.Request) {
+	var req drawsRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, flopOrTurn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	all := append(append([]poker.Card{}, hole...), community...)
+
+	ranks := make([]poker.Rank, len(all))
+	for i, c := range all {
+		ranks[i] = c.Rank
+	}
+	draw, outs := poker.StraightDrawType(ranks)
+	writeJSON(w, drawsResponse{
+		StraightDraw:  draw,
+		StraightOuts:  outs,
+		FlushDrawOuts: len(poker.FlushDrawOuts(hole, community)),
+	})
+}
+
+type oddsResponse struct {
+	Draws         []oddsDraw `json:"draws"`
+	HitByRiverPct float64    `json:"hitByRiverPct"` // any out by the river, shared outs counted once
+}
+
+type oddsDraw struct {
+	Type  string   `json:"type"` // flush draw, open-ended, double gutshot, gutshot or overcards
+	Outs  int      `json:"outs"`
+	Cards []string `json:"cards"`
+}
+
+func handleOdds(w http.ResponseWriter, r *http.Request) {
+	var req drawsRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	hole, community, err := decodeHand(req.Hole, req.Community, flopOrTurn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	draws := poker.DetectDraws(hole, community)
+	resp := oddsResponse{
+		Draws:         []oddsDraw{},
+		HitByRiverPct: poker.DrawHitProbability(hole, community, draws) * 100.0,
+	}
+	for _, d := range draws {
+		resp.Draws = append(resp.Draws, oddsDraw{Type: d.Type, Outs: d.Outs, Cards: cardsToStrings(d.Cards)})
+	}
+	writeJSON(w, resp)
+}
+
+type pushFoldRequest struct {
+	Hole          []string `json:"hole"`          // hero hole (2)
+	StackBB       float64  `json:"stackBB"`       // effective stack in big blinds
+	PlayersBehind int      `json:"playersBehind"` // 1-8, players still to act
+	CallRangePct  float64  `json:"callRangePct"`  // each caller's range; default poker.DefaultCallRangePct
+	Trials        int      `json:"trials"`
+	Seed          int64    `json:"seed"` // zero picks a fresh seed
+}
+
+type pushFoldResponse struct {
+	Action string  `json:"action"` // "push" or "fold"
+	PushEV float64 `json:"pushEV"` // expected stack in big blinds after jamming
+	FoldEV float64 `json:"foldEV"` // stack in big blinds after folding
+}
+
+func handlePushFold(w http.ResponseWriter, r *http.Request) {
+	var req pushFoldRequest
+	if !decodePost(w, r, &req) {
+		return
+	}
+
+	if req.StackBB <= 0 {
+		http.Error(w, "stackBB must be > 0", http.StatusBadRequest)
+		return
+	}
+	if req.PlayersBehind < 1 || req.PlayersBehind > 8 {
+		http.Error(w, "playersBehind must be between 1 and 8", http.StatusBadRequest)
+		return
+	}
+	if req.CallRangePct == 0 {
+		req.CallRangePct = poker.DefaultCallRangePct
+	}
+	if req.CallRangePct < 0 || req.CallRangePct > 100 {
+		http.Error(w, "callRangePct must be between 0 and 100", http.StatusBadRequest)
+		return
+	}
+	if req.Trials <= 0 || req.Trials > maxTrials {
+		http.Error(w, fmt.Sprintf("trials must be between 1 and %d", maxTrials), http.StatusBadRequest)
+		return
+	}
+
+	hole, err := parseHole("hero hole", req.Hole)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkDistinct(hole); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	push, fold := poker.PushFoldEV(hole, req.StackBB, req.PlayersBehind, req.CallRangePct, req.Trials, req.Seed)
+	action := "fold"
+	if push > fold {
+		action = "push"
+	}
+	writeJSON(w, pushFoldResponse{Action: action, PushEV: push, FoldEV: fold})
+}
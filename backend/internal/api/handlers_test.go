@@ -0,0 +1,965 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/texas-holdem-backend/internal/logging"
+)
+
+// newTestMux returns a mux with every API route registered and logging
+// discarded.
+func newTestMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, logging.New(io.Discard, logging.Error))
+	return mux
+}
+
+// post sends body to path as JSON and returns the recorded response.
+func post(t testing.TB, mux http.Handler, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+// decode fails the test unless rec is a 200 and unmarshals its body into v.
+func decode(t testing.TB, rec *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body, err)
+	}
+}
+
+// badRequest is a request body an endpoint must reject with a 400 whose
+// message contains want.
+type badRequest struct {
+	name, body, want string
+}
+
+func expectBadRequests(t *testing.T, path string, tests []badRequest) {
+	t.Helper()
+	mux := newTestMux()
+	for _, tt := range tests {
+		rec := post(t, mux, apiV1Prefix+path, tt.body)
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("%s %s: status %d %q, want 400 containing %q", path, tt.name, rec.Code, rec.Body, tt.want)
+		}
+	}
+}
+
+func TestSimulateEnumeratesHeadsUpTurnAndRiver(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		community string
+		method    string
+	}{
+		{`["2c", "7d", "9h", "Js", "3c"]`, "exact"},
+		{`["2c", "7d", "9h", "Js"]`, "exact"},
+		{`["2c", "7d", "9h"]`, "monte_carlo"},
+	}
+	for _, tt := range tests {
+		var resp simulateResponse
+		decode(t, post(t, mux, apiV1Prefix+"/simulate", `{"hole": ["Ah", "Ad"], "community": `+tt.community+`, "numOpponents": 1, "trials": 500}`), &resp)
+		if resp.Method != tt.method {
+			t.Errorf("%s: method %q, want %q", tt.community, resp.Method, tt.method)
+		}
+		if sum := resp.HeroWinPct + resp.VillainWinPct + resp.TiePct; math.Abs(sum-100) > 1e-9 {
+			t.Errorf("%s: percentages sum to %v", tt.community, sum)
+		}
+	}
+
+	// Heads-up on the turn: 46 river cards times 990 villain holdings.
+	var resp simulateResponse
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", `{"hole": ["Ah", "Ad"], "community": ["2c", "7d", "9h", "Js"], "numOpponents": 1, "trials": 500}`), &resp)
+	if resp.TrialsRun != 46*990 {
+		t.Errorf("turn: trialsRun %d, want %d", resp.TrialsRun, 46*990)
+	}
+}
+
+func TestTopHands(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		query string
+		code  int
+		count int
+	}{
+		{"", http.StatusOK, 10},
+		{"?count=3", http.StatusOK, 3},
+		{"?count=169", http.StatusOK, 169},
+		{"?count=0", http.StatusBadRequest, 0},
+		{"?count=170", http.StatusBadRequest, 0},
+		{"?count=ten", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, apiV1Prefix+"/top-hands"+tt.query, nil))
+		if rec.Code != tt.code {
+			t.Errorf("%q: status %d, want %d", tt.query, rec.Code, tt.code)
+			continue
+		}
+		if tt.code != http.StatusOK {
+			continue
+		}
+		var resp topHandsResponse
+		decode(t, rec, &resp)
+		if len(resp.Hands) != tt.count || resp.Hands[0].Name != "AA" {
+			t.Errorf("%q: %d hands starting %+v", tt.query, len(resp.Hands), resp.Hands[0])
+		}
+		for i := 1; i < len(resp.Hands); i++ {
+			if resp.Hands[i].Equity > resp.Hands[i-1].Equity {
+				t.Errorf("%q: hands out of order at %d", tt.query, i)
+			}
+		}
+	}
+	if rec := post(t, mux, apiV1Prefix+"/top-hands", "{}"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /top-hands = %d, want 405", rec.Code)
+	}
+}
+
+// postPaths are the endpoints that only accept POST.
+var postPaths = []string{
+	"/evaluate", "/winner", "/simulate", "/board-texture", "/equity-curve",
+	"/min-beating-hand", "/evaluate-draw", "/table-showdown", "/nut-gap",
+	"/evaluate-batch", "/blocker-effect", "/range-equity-exact",
+	"/hand-vs-range", "/clean-outs", "/payout", "/mdf",
+	"/import-hand", "/current-best-odds", "/equity-sources",
+	"/equity-comparison", "/play-hand", "/best-bet", "/evaluate-state",
+	"/card-impact", "/run-it-multiple", "/features", "/showdown-full",
+	"/draws", "/validate-hand", "/push-fold", "/equity-table", "/odds",
+	"/equity", "/evaluate-wild", "/range-vs-range", "/wawb",
+}
+
+func TestHealthz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestMux().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("GET /healthz = %d %q", rec.Code, rec.Body)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestMux().ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, apiV1Prefix+"/evaluate", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("OPTIONS status %d, want 204", rec.Code)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	mux := newTestMux()
+	for _, path := range postPaths {
+		for _, prefix := range []string{apiV1Prefix, legacyAPIPrefix} {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, prefix+path, nil))
+			if rec.Code != http.StatusMethodNotAllowed {
+				t.Errorf("GET %s%s = %d, want 405", prefix, path, rec.Code)
+			}
+		}
+	}
+}
+
+func TestInvalidJSON(t *testing.T) {
+	mux := newTestMux()
+	for _, path := range postPaths {
+		rec := post(t, mux, apiV1Prefix+path, "{")
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "invalid") {
+			t.Errorf("POST %s with bad JSON = %d %q", path, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestLegacyPrefix(t *testing.T) {
+	mux := newTestMux()
+	body := `{"hole": ["Ah", "Kh"], "community": ["Qh", "Jh", "Th", "2c", "3d"]}`
+
+	v1 := post(t, mux, apiV1Prefix+"/evaluate", body)
+	if v1.Header().Get("Deprecation") != "" {
+		t.Errorf("%s/evaluate is marked deprecated", apiV1Prefix)
+	}
+
+	legacy := post(t, mux, legacyAPIPrefix+"/evaluate", body)
+	if legacy.Code != http.StatusOK || legacy.Body.String() != v1.Body.String() {
+		t.Errorf("legacy /evaluate = %d %q, want %q", legacy.Code, legacy.Body, v1.Body)
+	}
+	if got := legacy.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("Deprecation = %q, want true", got)
+	}
+	if got, want := legacy.Header().Get("Link"), `</api/v1/evaluate>; rel="successor-version"`; got != want {
+		t.Errorf("Link = %q, want %q", got, want)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		hole, community string
+		category        string
+		kickers         string
+	}{
+		{`["Ah", "Kh"]`, `["Qh", "Jh", "Th", "2c", "3d"]`, "Straight Flush", "A"},
+		{`["Ah", "Ad"]`, `["Ac", "7h", "7d", "2c", "3d"]`, "Full House", "A7"},
+		{`["Ah", "Kd"]`, `["9c", "7h", "5d", "3c", "2s"]`, "High Card", "AK975"},
+	}
+	for _, tt := range tests {
+		var resp evaluateResponse
+		decode(t, post(t, mux, apiV1Prefix+"/evaluate", `{"hole": `+tt.hole+`, "community": `+tt.community+`}`), &resp)
+		if resp.Category != tt.category || strings.Join(resp.Kickers, "") != tt.kickers {
+			t.Errorf("%s %s: got %s %v, want %s %s", tt.hole, tt.community, resp.Category, resp.Kickers, tt.category, tt.kickers)
+		}
+	}
+
+	expectBadRequests(t, "/evaluate", []badRequest{
+		{"one hole card", `{"hole": ["Ah"], "community": ["2c", "3d", "4h", "5s", "9c"]}`, "hero hole must be 2 cards"},
+		{"bad card", `{"hole": ["Ah", "Zz"], "community": ["2c", "3d", "4h", "5s", "9c"]}`, "invalid hero hole"},
+		{"flop only", `{"hole": ["Ah", "Kd"], "community": ["2c", "3d", "4h"]}`, "community must be 5 cards"},
+	})
+}
+
+func TestWinner(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		body, winner string
+	}{
+		{`{"player1Hole": ["Ah", "Ad"], "player2Hole": ["Kh", "Kd"], "community": ["2c", "7d", "9h", "Js", "3c"]}`, "player1"},
+		{`{"player1Hole": ["2h", "3d"], "player2Hole": ["Kh", "Kd"], "community": ["2c", "7d", "9h", "Js", "3c"]}`, "player1"},
+		{`{"player1Hole": ["Qh", "Qd"], "player2Hole": ["Kh", "Kd"], "community": ["2c", "7d", "9h", "Js", "3c"]}`, "player2"},
+	}
+	for _, tt := range tests {
+		var resp winnerResponse
+		decode(t, post(t, mux, apiV1Prefix+"/winner", tt.body), &resp)
+		if resp.Winner != tt.winner || len(resp.Player1Best) != 5 || len(resp.Player2Best) != 5 {
+			t.Errorf("%s: got %+v, want %s", tt.body, resp, tt.winner)
+		}
+	}
+
+	expectBadRequests(t, "/winner", []badRequest{
+		{"short board", `{"player1Hole": ["Ah", "Ad"], "player2Hole": ["Kh", "Kd"], "community": ["2c", "7d", "9h"]}`, "community must be 5 cards"},
+		{"bad card", `{"player1Hole": ["Ah", "Zz"], "player2Hole": ["Kh", "Kd"], "community": ["2c", "7d", "9h", "Js", "3c"]}`, "invalid player1 hole"},
+		{"shared card", `{"player1Hole": ["Ah", "Ad"], "player2Hole": ["Ah", "Kd"], "community": ["2c", "7d", "9h", "Js", "3c"]}`, "duplicate cards"},
+	})
+}
+
+func TestSimulateBadRequests(t *testing.T) {
+	expectBadRequests(t, "/simulate", []badRequest{
+		{"no opponents", `{"hole": ["Ah", "Kh"], "trials": 100}`, "numOpponents must be >= 1"},
+		{"no trials", `{"hole": ["Ah", "Kh"], "numOpponents": 1}`, "trials must be between 1 and"},
+		{"too many trials", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 1000001}`, "trials must be between 1 and 1000000"},
+		{"bad card", `{"hole": ["Ah", "Zz"], "numOpponents": 1, "trials": 100}`, "invalid hero hole"},
+	})
+}
+
+func TestBoardTexture(t *testing.T) {
+	mux := newTestMux()
+
+	var rainbow, suited boardTextureResponse
+	decode(t, post(t, mux, apiV1Prefix+"/board-texture", `{"community": ["Kc", "7d", "2h"]}`), &rainbow)
+	decode(t, post(t, mux, apiV1Prefix+"/board-texture", `{"community": ["Th", "9h", "8h", "2c"]}`), &suited)
+	if rainbow.FourStraightPct != 0 || rainbow.PairedPct <= 0 || rainbow.FlushPossiblePct >= suited.FlushPossiblePct {
+		t.Errorf("K72 rainbow: %+v", rainbow)
+	}
+	if suited.FlushPossiblePct != 100 || suited.FourStraightPct <= 0 {
+		t.Errorf("T98 of hearts: %+v", suited)
+	}
+
+	expectBadRequests(t, "/board-texture", []badRequest{
+		{"two cards", `{"community": ["Ah", "Kh"]}`, "community must be 3 or 4 cards"},
+		{"river", `{"community": ["Ah", "Kh", "Qh", "Jh", "Th"]}`, "community must be 3 or 4 cards"},
+		{"duplicates", `{"community": ["Ah", "Ah", "4h"]}`, "duplicate cards"},
+	})
+}
+
+func TestSimulateVillainRangePct(t *testing.T) {
+	mux := newTestMux()
+	var any, tight simulateResponse
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", `{"hole": ["Jh", "Td"], "numOpponents": 1, "trials": 3000, "seed": 1}`), &any)
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", `{"hole": ["Jh", "Td"], "numOpponents": 1, "trials": 3000, "seed": 1, "villainRangePct": 5}`), &tight)
+	if tight.HeroWinPct >= any.HeroWinPct {
+		t.Errorf("JTo: %v vs the top 5%%, %v vs any two cards", tight.HeroWinPct, any.HeroWinPct)
+	}
+
+	expectBadRequests(t, "/simulate", []badRequest{
+		{"over 100", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "villainRangePct": 101}`, "villainRangePct must be between 0 and 100"},
+		{"with antithetic", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "antithetic": true, "villainRangePct": 10}`, "villain ranges are only supported"},
+		{"omaha", `{"game": "omaha", "hole": ["Ah", "Kh", "Qd", "Jc"], "numOpponents": 1, "trials": 100, "villainRangePct": 10}`, "villain ranges are only supported"},
+	})
+}
+
+func TestEquityCurve(t *testing.T) {
+	var resp equityCurveResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/equity-curve", `{"hole": ["Ah", "Ad"], "maxOpponents": 4, "trials": 1000}`), &resp)
+	if len(resp.Points) != 4 {
+		t.Fatalf("got %d points, want 4", len(resp.Points))
+	}
+	for i, p := range resp.Points {
+		if p.NumOpponents != i+1 || math.Abs(p.HeroWinPct+p.VillainWinPct+p.TiePct-100) > 1e-9 {
+			t.Errorf("point %d: %+v", i, p)
+		}
+	}
+	if resp.Points[0].HeroWinPct <= resp.Points[3].HeroWinPct {
+		t.Errorf("aces win %v heads-up but %v against four", resp.Points[0].HeroWinPct, resp.Points[3].HeroWinPct)
+	}
+
+	expectBadRequests(t, "/equity-curve", []badRequest{
+		{"one hole card", `{"hole": ["Ah"], "trials": 10}`, "hero hole must be 2 cards"},
+		{"two-card board", `{"hole": ["Ah", "Kh"], "community": ["2c", "3d"], "trials": 10}`, "community must be 0, 3, 4, or 5 cards"},
+		{"too many opponents", `{"hole": ["Ah", "Kh"], "maxOpponents": 23, "trials": 10}`, "maxOpponents must be between 1 and 22"},
+		{"no trials", `{"hole": ["Ah", "Kh"]}`, "trials must be between 1 and"},
+		{"too many trials", `{"hole": ["Ah", "Kh"], "trials": 1000001}`, "trials must be between 1 and 1000000"},
+		{"duplicates", `{"hole": ["Ah", "Kh"], "community": ["Ah", "3d", "4h"], "trials": 10}`, "duplicate cards"},
+	})
+}
+
+func TestSimulateSeedReplays(t *testing.T) {
+	mux := newTestMux()
+	body := `{"hole": ["Ah", "Kh"], "numOpponents": 2, "trials": 2000, "seed": %d}`
+
+	// A clock-seeded run reports its seed, and replaying that seed gives
+	// the same result.
+	var first, replay simulateResponse
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", strings.Replace(body, "%d", "0", 1)), &first)
+	if first.Method != "monte_carlo" || first.SeedUsed == 0 || first.TrialsRun != 2000 {
+		t.Fatalf("preflop: %+v", first)
+	}
+	seed, _ := json.Marshal(first.SeedUsed)
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", strings.Replace(body, "%d", string(seed), 1)), &replay)
+	if replay.HeroWinPct != first.HeroWinPct || replay.SeedUsed != first.SeedUsed || replay.TrialsRun != first.TrialsRun {
+		t.Errorf("replay of seed %d: %+v, want %+v", first.SeedUsed, replay, first)
+	}
+
+	// Exact results have no seed.
+	var exact simulateResponse
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", `{"hole": ["Ah", "Ad"], "community": ["2c", "7d", "9h", "Js", "3c"], "numOpponents": 1, "trials": 100, "seed": 5}`), &exact)
+	if exact.Method != "exact" || exact.SeedUsed != 0 {
+		t.Errorf("river: method %q, seedUsed %d; want exact, 0", exact.Method, exact.SeedUsed)
+	}
+	if !strings.Contains(post(t, mux, apiV1Prefix+"/simulate", `{"hole": ["Ah", "Ad"], "community": ["2c", "7d", "9h", "Js", "3c"], "numOpponents": 1, "trials": 100}`).Body.String(), `"seedUsed":0`) {
+		t.Error("exact result omits seedUsed")
+	}
+}
+
+func TestEvaluateUnused(t *testing.T) {
+	var resp evaluateResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/evaluate", `{"hole": ["2h", "Kc"], "community": ["6h", "9h", "Jh", "Kh", "Qd"]}`), &resp)
+	if resp.Category != "Flush" || strings.Join(resp.Unused, " ") != "CK DQ" {
+		t.Errorf("got %s, unused %v; want a flush leaving CK DQ", resp.Category, resp.Unused)
+	}
+}
+
+func TestWinnerSplitReason(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		body, reason string
+	}{
+		{`{"player1Hole": ["2h", "3d"], "player2Hole": ["4h", "5d"], "community": ["Ac", "Kd", "Qh", "Js", "Tc"]}`, "both_play_board"},
+		{`{"player1Hole": ["Ah", "Kd"], "player2Hole": ["Ad", "Kc"], "community": ["2c", "7d", "9h", "Js", "3c"]}`, "identical_hands"},
+	}
+	for _, tt := range tests {
+		var resp winnerResponse
+		decode(t, post(t, mux, apiV1Prefix+"/winner", tt.body), &resp)
+		if resp.Winner != "tie" || resp.SplitReason != tt.reason {
+			t.Errorf("%s: got %+v, want a tie with %q", tt.body, resp, tt.reason)
+		}
+	}
+
+	rec := post(t, mux, apiV1Prefix+"/winner", `{"player1Hole": ["Ah", "Ad"], "player2Hole": ["Kh", "Kd"], "community": ["2c", "7d", "9h", "Js", "3c"]}`)
+	if strings.Contains(rec.Body.String(), "splitReason") {
+		t.Errorf("no tie but %s", rec.Body)
+	}
+}
+
+func TestMinBeatingHand(t *testing.T) {
+	mux := newTestMux()
+
+	var nuts minBeatingHandResponse
+	decode(t, post(t, mux, apiV1Prefix+"/min-beating-hand", `{"community": ["Ah", "Kh", "Qh", "Jh", "2c"], "opponentHole": ["Th", "3d"]}`), &nuts)
+	if nuts.Found {
+		t.Errorf("opponent holds a royal flush, found %+v", nuts)
+	}
+	var beat minBeatingHandResponse
+	decode(t, post(t, mux, apiV1Prefix+"/min-beating-hand", `{"community": ["Ah", "Kd", "7c", "4s", "2c"], "opponentHole": ["2h", "3d"]}`), &beat)
+	if !beat.Found || len(beat.Hole) != 2 || beat.Category != "One Pair" {
+		t.Errorf("over a pair of twos: %+v", beat)
+	}
+
+	expectBadRequests(t, "/min-beating-hand", []badRequest{
+		{"flop", `{"community": ["Ah", "Kh", "Qh"], "opponentHole": ["2c", "3d"]}`, "community must be 5 cards"},
+		{"duplicates", `{"community": ["Ah", "Kh", "Qh", "Jh", "2c"], "opponentHole": ["2c", "3d"]}`, "duplicate cards"},
+	})
+}
+
+func TestNutGap(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		hole        string
+		nuts        bool
+		nutCategory string
+	}{
+		{`["Th", "3d"]`, true, "Straight Flush"},
+		{`["2d", "3d"]`, false, "Straight Flush"},
+	}
+	for _, tt := range tests {
+		var resp nutGapResponse
+		decode(t, post(t, mux, apiV1Prefix+"/nut-gap", `{"hole": `+tt.hole+`, "community": ["Ah", "Kh", "Qh", "Jh", "2c"]}`), &resp)
+		if (resp.Gap == 0) != tt.nuts || resp.NutCategory != tt.nutCategory {
+			t.Errorf("%s: %+v", tt.hole, resp)
+		}
+	}
+
+	expectBadRequests(t, "/nut-gap", []badRequest{
+		{"flop", `{"hole": ["Ah", "Kh"], "community": ["2c", "3d", "4h"]}`, "community must be 5 cards"},
+		{"duplicates", `{"hole": ["Ah", "Kh"], "community": ["Ah", "3d", "4h", "5s", "9c"]}`, "duplicate cards"},
+	})
+}
+
+func TestRequireJSON(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		contentType string
+		want        int
+	}{
+		{"", http.StatusUnsupportedMediaType},
+		{"text/plain", http.StatusUnsupportedMediaType},
+		{"application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"application/json", http.StatusOK},
+		{"application/json; charset=utf-8", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, apiV1Prefix+"/evaluate", strings.NewReader(`{"hole": ["Ah", "Kh"], "community": ["Qh", "Jh", "Th", "2c", "3d"]}`))
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("Content-Type %q: status %d, want %d", tt.contentType, rec.Code, tt.want)
+		}
+	}
+
+	// GET requests carry no body and need no Content-Type.
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, apiV1Prefix+"/top-hands", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /top-hands: status %d", rec.Code)
+	}
+}
+
+func TestEvaluateBatch(t *testing.T) {
+	mux := newTestMux()
+	body := `{"hands": [
+		{"hole": ["2c", "7d"], "community": ["9h", "Js", "3c", "4d", "Kh"]},
+		{"hole": ["Ah", "Kh"], "community": ["Qh", "Jh", "Th", "2c", "3d"]},
+		{"hole": ["Ac", "Ad"], "community": ["As", "7h", "7d", "2c", "3d"]}
+	]%s}`
+	tests := []struct {
+		opts    string
+		indexes []int
+	}{
+		{"", []int{0, 1, 2}},
+		{`, "sort": "strength"`, []int{1, 2, 0}},
+		{`, "sort": "strength", "offset": 1, "limit": 1`, []int{2}},
+		{`, "offset": 5`, []int{}},
+	}
+	for _, tt := range tests {
+		var resp batchEvaluateResponse
+		decode(t, post(t, mux, apiV1Prefix+"/evaluate-batch", strings.Replace(body, "%s", tt.opts, 1)), &resp)
+		got := []int{}
+		for _, r := range resp.Results {
+			got = append(got, r.Index)
+		}
+		if resp.Total != 3 || !equalInts(got, tt.indexes) {
+			t.Errorf("%q: total %d, indexes %v; want %v", tt.opts, resp.Total, got, tt.indexes)
+		}
+	}
+
+	hand := `{"hole": ["Ah", "Kh"], "community": ["2c", "3d", "4h", "5s", "9c"]}`
+	expectBadRequests(t, "/evaluate-batch", []badRequest{
+		{"no hands", `{"hands": []}`, "require between 1 and 10000 hands"},
+		{"bad sort", `{"hands": [` + hand + `], "sort": "name"}`, "sort must be"},
+		{"negative offset", `{"hands": [` + hand + `], "offset": -1}`, "offset and limit must be >= 0"},
+		{"short hand", `{"hands": [` + hand + `, {"hole": ["Ah"], "community": []}]}`, "hand 1: hero hole must be 2 cards"},
+		{"duplicates", `{"hands": [` + hand + `, {"hole": ["Ah", "Ah"], "community": ["2c", "3d", "4h", "5s", "9c"]}]}`, "hand 1: duplicate cards"},
+	})
+	expectBadRequests(t, "/evaluate", []badRequest{
+		{"duplicates", `{"hole": ["Ah", "Ah"], "community": ["2c", "3d", "4h", "5s", "9c"]}`, "duplicate cards"},
+	})
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSimulateTrackFinish(t *testing.T) {
+	var resp simulateResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/simulate", `{"hole": ["Ah", "Kh"], "numOpponents": 3, "trials": 500, "seed": 1, "trackFinish": true}`), &resp)
+	total := 0
+	for _, n := range resp.FinishCounts {
+		total += n
+	}
+	if len(resp.FinishCounts) != 4 || total != resp.TrialsRun {
+		t.Errorf("finishCounts %v over %d trials", resp.FinishCounts, resp.TrialsRun)
+	}
+
+	expectBadRequests(t, "/simulate", []badRequest{
+		{"with a range", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "trackFinish": true, "villainRangePct": 10}`, "trackFinish cannot be combined"},
+	})
+}
+
+func TestSimulateDebug(t *testing.T) {
+	mux := newTestMux()
+	var resp simulateResponse
+	decode(t, post(t, mux, apiV1Prefix+"/simulate?debug=true", `{"hole": ["Ah", "Ad"], "community": ["2c", "7d", "9h", "Js", "3c"], "numOpponents": 1, "trials": 100}`), &resp)
+	if resp.Method != "monte_carlo" || resp.Debug == nil {
+		t.Fatalf("debug run: %+v", resp)
+	}
+	if resp.Debug.Workers != 1 || len(resp.Debug.Samples) != debugSampleTrials {
+		t.Errorf("debug: workers %d, %d samples", resp.Debug.Workers, len(resp.Debug.Samples))
+	}
+	for i, s := range resp.Debug.Samples {
+		if len(s.Board) != 5 || len(s.Opponents) != 1 || (s.Outcome != "win" && s.Outcome != "loss" && s.Outcome != "tie") {
+			t.Errorf("sample %d: %+v", i, s)
+		}
+	}
+
+	rec := post(t, mux, apiV1Prefix+"/simulate?debug=true", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "villainRangePct": 10}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("debug with a villain range: status %d, want 400", rec.Code)
+	}
+}
+
+func TestEvaluatePercentile(t *testing.T) {
+	mux := newTestMux()
+	var royal, weak evaluateResponse
+	decode(t, post(t, mux, apiV1Prefix+"/evaluate", `{"hole": ["Ah", "Kh"], "community": ["Qh", "Jh", "Th", "2c", "3d"]}`), &royal)
+	decode(t, post(t, mux, apiV1Prefix+"/evaluate", `{"hole": ["7h", "5d"], "community": ["4c", "3s", "9h", "Jc", "2d"]}`), &weak)
+	if royal.Percentile != 1 || weak.Percentile <= 0 || weak.Percentile >= 0.5 {
+		t.Errorf("percentiles: royal flush %v, jack high %v", royal.Percentile, weak.Percentile)
+	}
+}
+
+func TestBoardTexturePossibleHands(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		community string
+		want      string
+	}{
+		{`["Kc", "7d", "2h"]`, "High Card,One Pair,Two Pair,Three of a Kind"},
+		{`["Th", "9h", "8h"]`, "Straight Flush"},
+	}
+	for _, tt := range tests {
+		var resp boardTextureResponse
+		decode(t, post(t, mux, apiV1Prefix+"/board-texture", `{"community": `+tt.community+`}`), &resp)
+		if got := strings.Join(resp.PossibleHands, ","); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: possible hands %s, want %s", tt.community, got, tt.want)
+		}
+	}
+}
+
+func TestSimulateSSE(t *testing.T) {
+	mux := newTestMux()
+	rec := post(t, mux, apiV1Prefix+"/sse/simulate", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 2000, "seed": 1}`)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status %d, Content-Type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+	last := events[len(events)-1]
+	if !strings.HasPrefix(last, "event: result\ndata: ") {
+		t.Fatalf("last event = %q", last)
+	}
+	var resp simulateResponse
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(last, "event: result\ndata: ")), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.TrialsRun != 2000 || resp.SeedUsed != 1 {
+		t.Errorf("result: %+v", resp)
+	}
+	for _, e := range events[:len(events)-1] {
+		if !strings.HasPrefix(e, "event: progress\ndata: ") {
+			t.Errorf("unexpected event %q", e)
+		}
+	}
+
+	if rec := post(t, mux, apiV1Prefix+"/sse/simulate", `{"hole": ["Ah"], "numOpponents": 1, "trials": 10}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad hole: status %d, want 400", rec.Code)
+	}
+	if rec := post(t, mux, apiV1Prefix+"/sse/simulate", "{"); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad JSON: status %d, want 400", rec.Code)
+	}
+}
+
+func TestSimulateSSEGet(t *testing.T) {
+	mux := newTestMux()
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, apiV1Prefix+"/sse/simulate?"+query, nil))
+		return rec
+	}
+
+	// The same request as TestSimulateSSE's, so the same seeded result.
+	rec := get("hole=Ah,Kh&numOpponents=1&trials=2000&seed=1&antithetic=false")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status %d, Content-Type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+	var resp simulateResponse
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(events[len(events)-1], "event: result\ndata: ")), &resp); err != nil {
+		t.Fatal(err)
+	}
+	posted := post(t, mux, apiV1Prefix+"/sse/simulate", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 2000, "seed": 1}`).Body.String()
+	if !strings.HasSuffix(posted, events[len(events)-1]+"\n\n") || resp.TrialsRun != 2000 {
+		t.Errorf("GET result %q differs from POST", events[len(events)-1])
+	}
+
+	for _, query := range []string{"hole=Ah&numOpponents=1&trials=10", "hole=Ah,Kh&numOpponents=one", "hole=Ah,Kh&numOpponents=1&trials=10&antithetic=maybe"} {
+		if rec := get(query); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", query, rec.Code)
+		}
+	}
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, apiV1Prefix+"/sse/simulate", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT: status %d, want 405", rec.Code)
+	}
+}
+
+func TestMDF(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		pot, bet    string
+		mdf, bluffs float64
+	}{
+		{"100", "50", 100.0 * 2 / 3, 25},
+		{"100", "100", 50, 100.0 / 3},
+	}
+	for _, tt := range tests {
+		var resp mdfResponse
+		decode(t, post(t, mux, apiV1Prefix+"/mdf", `{"pot": `+tt.pot+`, "bet": `+tt.bet+`}`), &resp)
+		if math.Abs(resp.MDFPct-tt.mdf) > 1e-9 || math.Abs(resp.BluffPct-tt.bluffs) > 1e-9 {
+			t.Errorf("pot %s bet %s: %+v, want mdf %v bluffs %v", tt.pot, tt.bet, resp, tt.mdf, tt.bluffs)
+		}
+	}
+
+	expectBadRequests(t, "/mdf", []badRequest{
+		{"negative pot", `{"pot": -1, "bet": 10}`, "pot and bet must be >= 0"},
+	})
+}
+
+func TestEvaluateWild(t *testing.T) {
+	var resp evaluateResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/evaluate-wild", `{"hole": ["?", "Kd"], "community": ["Qc", "Js", "9h", "3c", "2d"]}`), &resp)
+	if resp.Category != "Straight" || strings.Join(resp.Kickers, "") != "K" {
+		t.Errorf("got %s %v, want a king-high straight", resp.Category, resp.Kickers)
+	}
+
+	expectBadRequests(t, "/evaluate-wild", []badRequest{
+		{"one hole card", `{"hole": ["?"], "community": ["2c", "3d", "4h", "5s", "9c"]}`, "exactly 2 hole cards"},
+		{"too many wilds", `{"hole": ["?", "?"], "community": ["?", "3d", "4h", "5s", "9c"]}`, ""},
+		{"duplicates", `{"hole": ["?", "3d"], "community": ["2c", "3d", "4h", "5s", "9c"]}`, ""},
+	})
+	expectBadRequests(t, "/evaluate", []badRequest{
+		{"wildcard", `{"hole": ["?", "Kd"], "community": ["Qc", "Js", "9h", "3c", "2d"]}`, "invalid card"},
+	})
+}
+
+func TestSimulateRNG(t *testing.T) {
+	mux := newTestMux()
+	body := `{"hole": ["Ah", "Kh"], "numOpponents": 2, "trials": 2000, "seed": 9, "rng": "xoshiro256"}`
+	var a, b simulateResponse
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", body), &a)
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", body), &b)
+	if a.HeroWinPct != b.HeroWinPct || a.TiePct != b.TiePct || a.SeedUsed != 9 {
+		t.Errorf("xoshiro256 seed 9: %+v vs %+v", a, b)
+	}
+
+	expectBadRequests(t, "/simulate", []badRequest{
+		{"unknown rng", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "rng": "pcg"}`, "unknown rng"},
+	})
+}
+
+func TestShowdownFull(t *testing.T) {
+	// Both aces play the board's K Q 7 kickers and chop; the kings lose.
+	var resp showdownFullResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/showdown-full", `{"players": [["Ah", "3c"], ["Ad", "3d"], ["Kh", "4c"]], "community": ["As", "Ks", "Qd", "7c", "2h"]}`), &resp)
+	if resp.Method != "exact" || !equalInts(resp.Winners, []int{1, 2}) || len(resp.Seats) != 3 {
+		t.Fatalf("showdown: %+v", resp)
+	}
+	for i, want := range []float64{50, 50, 0} {
+		seat := resp.Seats[i]
+		if seat.Seat != i+1 || seat.Folded || seat.PotSharePct != want || len(seat.BestFive) != 5 {
+			t.Errorf("seat %d: %+v, want a %v%% share", i+1, seat, want)
+		}
+	}
+	if resp.Seats[0].Category != "One Pair" || resp.Seats[2].Category != "One Pair" {
+		t.Errorf("categories %q, %q", resp.Seats[0].Category, resp.Seats[2].Category)
+	}
+}
+
+func TestSimulateVillainWeightedRange(t *testing.T) {
+	// Aces against kings only win about 82% of the time.
+	var resp simulateResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/simulate", `{"hole": ["Ah", "Ad"], "numOpponents": 1, "trials": 4000, "seed": 1, "villainWeightedRange": "KK"}`), &resp)
+	if resp.HeroWinPct < 76 || resp.HeroWinPct > 88 {
+		t.Errorf("AA vs KK: heroWinPct %v, want about 82", resp.HeroWinPct)
+	}
+
+	expectBadRequests(t, "/simulate", []badRequest{
+		{"both ranges", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "villainRangePct": 10, "villainWeightedRange": "QQ"}`, "not both"},
+		{"bad weight", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "villainWeightedRange": "QQ:2"}`, "invalid villainWeightedRange"},
+		{"every combo blocked", `{"hole": ["Kh", "Kd"], "community": ["Kc", "Ks", "2d"], "numOpponents": 1, "trials": 100, "villainWeightedRange": "KK"}`, "every villain combo conflicts"},
+	})
+}
+
+func TestRangeVsRange(t *testing.T) {
+	var resp rangeVsRangeResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/range-vs-range", `{"heroRange": "AA", "villainRange": "KK", "trials": 4000}`), &resp)
+	if resp.HeroEquityPct < 76 || resp.HeroEquityPct > 88 {
+		t.Errorf("AA vs KK: heroEquityPct %v, want about 82", resp.HeroEquityPct)
+	}
+
+	expectBadRequests(t, "/range-vs-range", []badRequest{
+		{"no trials", `{"heroRange": "AA", "villainRange": "KK"}`, "trials must be between 1 and"},
+		{"too many trials", `{"heroRange": "AA", "villainRange": "KK", "trials": 1000001}`, "trials must be between 1 and 1000000"},
+		{"bad range", `{"heroRange": "AA", "villainRange": "XYZ", "trials": 10}`, "invalid villainRange"},
+		{"blocked by the board", `{"heroRange": "AA", "villainRange": "KK", "community": ["Kh", "Kd", "Kc"], "trials": 10}`, "conflicts with the board"},
+	})
+}
+
+func TestHandMatrixRejects(t *testing.T) {
+	mux := newTestMux()
+	for _, query := range []string{"?villainRangePct=101", "?trials=0", "?trials=10001", "?trials=many"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, apiV1Prefix+"/hand-matrix"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%q: status %d, want 400", query, rec.Code)
+		}
+	}
+	if rec := post(t, mux, apiV1Prefix+"/hand-matrix", "{}"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /hand-matrix = %d, want 405", rec.Code)
+	}
+}
+
+func TestDraws(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		hole, community string
+		want            drawsResponse
+	}{
+		{`["Ah", "Kh"]`, `["7h", "2h", "9c"]`, drawsResponse{StraightDraw: "none", FlushDrawOuts: 9}},
+		{`["9c", "8d"]`, `["Jh", "Ts", "2c"]`, drawsResponse{StraightDraw: "open-ended", StraightOuts: 8}},
+		{`["9c", "7d"]`, `["Jh", "Ts", "2c", "3d"]`, drawsResponse{StraightDraw: "gutshot", StraightOuts: 4}},
+	}
+	for _, tt := range tests {
+		var resp drawsResponse
+		decode(t, post(t, mux, apiV1Prefix+"/draws", `{"hole": `+tt.hole+`, "community": `+tt.community+`}`), &resp)
+		if resp != tt.want {
+			t.Errorf("%s %s: got %+v, want %+v", tt.hole, tt.community, resp, tt.want)
+		}
+	}
+
+	expectBadRequests(t, "/draws", []badRequest{
+		{"river", `{"hole": ["Ah", "Kh"], "community": ["2c", "3d", "4h", "5s", "9c"]}`, "community must be 3 or 4 cards"},
+		{"duplicates", `{"hole": ["Ah", "Kh"], "community": ["Kh", "3d", "4h"]}`, "duplicate cards"},
+	})
+}
+
+func TestValidateHand(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		body   string
+		fields []string
+	}{
+		{`{"hole": ["Ah", "Kh"], "community": ["2c", "3d", "4h"]}`, nil},
+		{`{"game": "omaha", "hole": ["Ah", "Kh", "Qd", "Jc"]}`, nil},
+		{`{"game": "omaha", "hole": ["Ah", "Kh"]}`, []string{"hole"}},
+		{`{"game": "stud", "hole": ["Ah", "Kh"]}`, []string{"game"}},
+		{`{"hole": ["Ah", "Zz"], "community": ["2c", "3d"]}`, []string{"community", "hole"}},
+		{`{"hole": ["Ah", "Kh"], "community": ["Ah", "3d", "4h"]}`, []string{"cards"}},
+	}
+	for _, tt := range tests {
+		var resp validateHandResponse
+		decode(t, post(t, mux, apiV1Prefix+"/validate-hand", tt.body), &resp)
+		var fields []string
+		for _, v := range resp.Violations {
+			fields = append(fields, v.Field)
+		}
+		if resp.Valid != (len(tt.fields) == 0) || strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+			t.Errorf("%s: valid %v, fields %v; want %v", tt.body, resp.Valid, fields, tt.fields)
+		}
+	}
+	if rec := post(t, mux, apiV1Prefix+"/validate-hand", `{"hole": ["Ah", "Kh"]}`); !strings.Contains(rec.Body.String(), `"violations":[]`) {
+		t.Errorf("valid hand: %s", rec.Body)
+	}
+
+	expectBadRequests(t, "/simulate", []badRequest{
+		{"duplicates", `{"hole": ["Ah", "Ah"], "numOpponents": 1, "trials": 100}`, "duplicate card"},
+		{"omaha hole", `{"game": "omaha", "hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100}`, "hero hole must be 4 cards"},
+	})
+}
+
+func TestSimulateVillainCard(t *testing.T) {
+	mux := newTestMux()
+	var plain, known simulateResponse
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", `{"hole": ["Ah", "Ad"], "community": ["2c", "7d", "9h"], "numOpponents": 1, "trials": 3000, "seed": 2}`), &plain)
+	decode(t, post(t, mux, apiV1Prefix+"/simulate", `{"hole": ["Ah", "Ad"], "community": ["2c", "7d", "9h"], "numOpponents": 1, "trials": 3000, "seed": 2, "villainCard": "7c"}`), &known)
+	if known.HeroWinPct >= plain.HeroWinPct {
+		t.Errorf("villain with a seven: %v, any two cards %v", known.HeroWinPct, plain.HeroWinPct)
+	}
+
+	expectBadRequests(t, "/simulate", []badRequest{
+		{"repeats hero's card", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "villainCard": "Ah"}`, "duplicate cards"},
+		{"bad card", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "villainCard": "Zz"}`, "invalid villainCard"},
+		{"with a range", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "villainCard": "Qc", "villainRangePct": 10}`, "villainCard cannot be combined"},
+	})
+}
+
+func TestPushFold(t *testing.T) {
+	mux := newTestMux()
+	tests := []struct {
+		hole, action string
+	}{
+		{`["Ah", "Ad"]`, "push"},
+		{`["7c", "2d"]`, "fold"},
+	}
+	for _, tt := range tests {
+		var resp pushFoldResponse
+		decode(t, post(t, mux, apiV1Prefix+"/push-fold", `{"hole": `+tt.hole+`, "stackBB": 20, "playersBehind": 8, "callRangePct": 10, "trials": 500, "seed": 1}`), &resp)
+		if resp.Action != tt.action {
+			t.Errorf("%s: %+v, want %s", tt.hole, resp, tt.action)
+		}
+	}
+
+	expectBadRequests(t, "/push-fold", []badRequest{
+		{"no stack", `{"hole": ["Ah", "Kh"], "stackBB": 0, "playersBehind": 1, "trials": 10}`, "stackBB must be > 0"},
+		{"too many players", `{"hole": ["Ah", "Kh"], "stackBB": 10, "playersBehind": 9, "trials": 10}`, "playersBehind must be between 1 and 8"},
+		{"duplicates", `{"hole": ["Ah", "Ah"], "stackBB": 10, "playersBehind": 1, "trials": 10}`, "duplicate cards"},
+	})
+}
+
+func TestBoardTextureWetness(t *testing.T) {
+	mux := newTestMux()
+	var dry, wet boardTextureResponse
+	decode(t, post(t, mux, apiV1Prefix+"/board-texture", `{"community": ["Kc", "7d", "2h"]}`), &dry)
+	decode(t, post(t, mux, apiV1Prefix+"/board-texture", `{"community": ["Th", "9h", "8d"]}`), &wet)
+	if dry.Wetness < 0 || wet.Wetness > 1 || dry.Wetness >= wet.Wetness {
+		t.Errorf("wetness: K72 rainbow %v, T98 two-tone %v", dry.Wetness, wet.Wetness)
+	}
+}
+
+func TestEquityTable(t *testing.T) {
+	var resp equityTableResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/equity-table", `{"hole": ["Ah", "Ad"], "maxOpponents": 3, "trials": 500, "betFractions": [0.5, 2]}`), &resp)
+	if len(resp.Opponents) != 3 || len(resp.EquityPct) != 3 || len(resp.Rows) != 2 {
+		t.Fatalf("got %+v", resp)
+	}
+	for _, row := range resp.Rows {
+		if len(row.Actions) != 3 || row.Actions[0] != "call" {
+			t.Errorf("aces at %v pot: %v", row.BetFraction, row.Actions)
+		}
+	}
+
+	expectBadRequests(t, "/equity-table", []badRequest{
+		{"zero fraction", `{"hole": ["Ah", "Kh"], "trials": 10, "betFractions": [0]}`, "betFractions must be > 0"},
+		{"too many trials", `{"hole": ["Ah", "Kh"], "trials": 1000001, "betFractions": [1]}`, "trials must be between 1 and 1000000"},
+		{"duplicates", `{"hole": ["Ah", "Kh"], "community": ["Ah", "3d", "4h"], "trials": 10}`, "duplicate cards"},
+	})
+}
+
+func TestSimulateScoreHistogram(t *testing.T) {
+	var resp simulateResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/simulate", `{"hole": ["Ah", "Kh"], "numOpponents": 3, "trials": 500, "seed": 1, "scoreBuckets": 4}`), &resp)
+	total := 0
+	for _, n := range resp.ScoreHistogram {
+		total += n
+	}
+	if len(resp.ScoreHistogram) != 4 || total != resp.TrialsRun {
+		t.Errorf("scoreHistogram %v over %d trials", resp.ScoreHistogram, resp.TrialsRun)
+	}
+
+	expectBadRequests(t, "/simulate", []badRequest{
+		{"too many buckets", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "scoreBuckets": 101}`, "scoreBuckets must be between 0 and 100"},
+		{"with a range", `{"hole": ["Ah", "Kh"], "numOpponents": 1, "trials": 100, "scoreBuckets": 4, "villainRangePct": 10}`, "scoreBuckets cannot be combined"},
+	})
+}
+
+func TestSimulateConfidenceInterval(t *testing.T) {
+	var resp simulateResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/simulate", `{"hole": ["Ah", "Kh"], "numOpponents": 2, "trials": 2000, "seed": 4}`), &resp)
+	if resp.HeroWinLow > resp.HeroWinPct || resp.HeroWinHigh < resp.HeroWinPct || resp.HeroWinLow == resp.HeroWinHigh {
+		t.Errorf("interval [%v, %v] around %v", resp.HeroWinLow, resp.HeroWinHigh, resp.HeroWinPct)
+	}
+}
+
+func TestOdds(t *testing.T) {
+	mux := newTestMux()
+
+	var resp oddsResponse
+	decode(t, post(t, mux, apiV1Prefix+"/odds", `{"hole": ["Ah", "Kh"], "community": ["Qh", "Jh", "2c"]}`), &resp)
+	var types []string
+	for _, d := range resp.Draws {
+		types = append(types, d.Type)
+		if len(d.Cards) != d.Outs {
+			t.Errorf("%s: %d outs but cards %v", d.Type, d.Outs, d.Cards)
+		}
+	}
+	if got := strings.Join(types, ","); got != "flush draw,gutshot,overcards" {
+		t.Errorf("draws = %s", got)
+	}
+	if want := (1 - 29.0/47.0*28.0/46.0) * 100; math.Abs(resp.HitByRiverPct-want) > 1e-9 {
+		t.Errorf("hitByRiverPct = %v, want %v", resp.HitByRiverPct, want)
+	}
+
+	// No draws is an empty list, not null.
+	rec := post(t, mux, apiV1Prefix+"/odds", `{"hole": ["2c", "7d"], "community": ["Ks", "Jh", "Td"]}`)
+	if !strings.Contains(rec.Body.String(), `"draws":[]`) {
+		t.Errorf("no draws: %s", rec.Body)
+	}
+
+	expectBadRequests(t, "/odds", []badRequest{
+		{"river", `{"hole": ["Ah", "Kh"], "community": ["2c", "3d", "4h", "5s", "9c"]}`, "community must be 3 or 4 cards"},
+		{"duplicates", `{"hole": ["Ah", "Kh"], "community": ["Ah", "3d", "4h"]}`, "duplicate cards"},
+	})
+}
+
+func TestEquity(t *testing.T) {
+	var resp equityResponse
+	decode(t, post(t, newTestMux(), apiV1Prefix+"/equity", `{"players": [["Ah", "Kd"], [], ["Ad", "Kc"], ["2h", "3d"]], "community": ["Ks", "7d", "9h", "Js", "Tc"]}`), &resp)
+	if len(resp.Players) != 4 {
+		t.Fatalf("players = %+v, want every seat", resp.Players)
+	}
+	shares := map[int]float64{}
+	for _, p := range resp.Players {
+		shares[p.Seat] = p.SharePct
+	}
+	if shares[1] != 50 || shares[2] != 0 || shares[3] != 50 || shares[4] != 0 {
+		t.Errorf("shares = %v, want seats 1 and 3 to chop", shares)
+	}
+
+	board := `"community": ["2c", "3d", "4h", "5s", "9c"]`
+	expectBadRequests(t, "/equity", []badRequest{
+		{"one player", `{"players": [["Ah", "Kh"]], ` + board + `}`, "require between 2 and 9 players"},
+		{"everyone folded", `{"players": [[], []], ` + board + `}`, "at least one seat"},
+		{"flop", `{"players": [["Ah", "Kh"], ["Qd", "Qc"]], "community": ["2c", "3d", "4h"]}`, "community must be 5 cards"},
+		{"duplicates", `{"players": [["Ah", "Kh"], ["Ah", "Qd"]], ` + board + `}`, "duplicate cards"},
+	})
+}
@@ -0,0 +1,92 @@
+// Package logging provides a small leveled wrapper around the standard log
+// package.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// Level is a logging severity. Messages below a Logger's level are dropped.
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+var levelNames = map[Level]string{
+	Debug: "DEBUG",
+	Info:  "INFO",
+	Warn:  "WARN",
+	Error: "ERROR",
+}
+
+// ParseLevel converts a level name (case-insensitive) into a Level. "quiet"
+// is an alias for "error"; an empty name means Info.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return Debug, nil
+	case "", "info":
+		return Info, nil
+	case "warn", "warning":
+		return Warn, nil
+	case "error", "quiet":
+		return Error, nil
+	}
+	return Info, fmt.Errorf("unknown log level: %s", s)
+}
+
+// Logger writes messages at or above its level, prefixed with the level
+// name.
+type Logger struct {
+	level Level
+	out   *log.Logger
+}
+
+// New returns a Logger writing to w with the standard log flags.
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{level: level, out: log.New(w, "", log.LstdFlags)}
+}
+
+// FromEnv returns a stderr Logger whose level comes from the LOG_LEVEL
+// environment variable, falling back to Info if it is unset or invalid.
+func FromEnv() (*Logger, error) {
+	level, err := ParseLevel(os.Getenv("LOG_LEVEL"))
+	return New(os.Stderr, level), err
+}
+
+// Enabled reports whether messages at level would be written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+func (l *Logger) logf(level Level, format string, args ...any) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.out.Printf(levelNames[level]+" "+format, args...)
+}
+
+// Debugf logs per-request and other development detail.
+func (l *Logger) Debugf(format string, args ...any) { l.logf(Debug, format, args...) }
+
+// Infof logs normal operational messages.
+func (l *Logger) Infof(format string, args ...any) { l.logf(Info, format, args...) }
+
+// Warnf logs unexpected but recoverable conditions.
+func (l *Logger) Warnf(format string, args ...any) { l.logf(Warn, format, args...) }
+
+// Errorf logs failures.
+func (l *Logger) Errorf(format string, args ...any) { l.logf(Error, format, args...) }
+
+// Fatalf logs at Error level regardless of the Logger's level and exits.
+func (l *Logger) Fatalf(format string, args ...any) {
+	l.out.Fatalf(levelNames[Error]+" "+format, args...)
+}
@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, Info)
+	l.Debugf("hidden %d", 1)
+	l.Infof("shown %d", 2)
+	l.Errorf("shown %d", 3)
+
+	out := buf.String()
+	if strings.Contains(out, "hidden") || strings.Contains(out, "DEBUG") {
+		t.Errorf("debug message written at info level: %q", out)
+	}
+	if !strings.Contains(out, "INFO shown 2") || !strings.Contains(out, "ERROR shown 3") {
+		t.Errorf("output %q, want the info and error messages", out)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		want Level
+	}{
+		{"", Info},
+		{"debug", Debug},
+		{"INFO", Info},
+		{"Warning", Warn},
+		{"quiet", Error},
+	}
+	for _, tt := range tests {
+		if got, err := ParseLevel(tt.name); got != tt.want || err != nil {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", tt.name, got, err, tt.want)
+		}
+	}
+	if got, err := ParseLevel("verbose"); got != Info || err == nil {
+		t.Errorf("ParseLevel(verbose) = %v, %v; want Info and an error", got, err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "debug")
+	if l, err := FromEnv(); err != nil || !l.Enabled(Debug) {
+		t.Errorf("LOG_LEVEL=debug: debug enabled %v, err %v", l.Enabled(Debug), err)
+	}
+
+	t.Setenv("LOG_LEVEL", "loud")
+	l, err := FromEnv()
+	if err == nil {
+		t.Error("LOG_LEVEL=loud: no error")
+	}
+	if l.Enabled(Debug) || !l.Enabled(Info) {
+		t.Errorf("LOG_LEVEL=loud: debug enabled %v, info enabled %v; want the Info fallback", l.Enabled(Debug), l.Enabled(Info))
+	}
+}
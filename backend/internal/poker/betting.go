@@ -0,0 +1,47 @@
+package poker
+
+import "fmt"
+
+// Betting structures accepted by LegalBetSizes.
+const (
+	NoLimit    = "no-limit"
+	PotLimit   = "pot-limit"
+	FixedLimit = "fixed-limit"
+)
+
+// LegalBetSizes returns the smallest and largest legal bet or raise, as the
+// total a player with no chips in yet on this street puts in, facing
+// currentBet (0 to open the betting). minRaise is the smallest raise
+// increment (the big blind or the last raise) and, in fixed-limit, the
+// fixed bet size. potLimit is the pot before the player acts, including
+// currentBet; it only matters in pot-limit, where the largest raise is the
+// call plus the pot after calling: currentBet + (potLimit + currentBet).
+// Both bounds are capped at stack, so a short player may only go all in.
+// It panics on an unknown structure.
+func LegalBetSizes(currentBet, minRaise, stack, potLimit int, structure string) (min, max int) {
+	if currentBet < 0 || minRaise <= 0 || stack < 0 || potLimit < 0 {
+		panic("bet amounts must be >= 0 and minRaise > 0")
+	}
+
+	min = currentBet + minRaise
+	switch structure {
+	case NoLimit:
+		max = stack
+	case PotLimit:
+		max = currentBet + potLimit + currentBet
+	case FixedLimit:
+		max = min
+	default:
+		panic(fmt.Sprintf("unknown betting structure: %s", structure))
+	}
+	if max < min {
+		max = min
+	}
+	if min > stack {
+		min = stack
+	}
+	if max > stack {
+		max = stack
+	}
+	return min, max
+}
@@ -0,0 +1,41 @@
+package poker
+
+import "testing"
+
+func TestLegalBetSizes(t *testing.T) {
+	tests := []struct {
+		name                             string
+		currentBet, minRaise, stack, pot int
+		structure                        string
+		min, max                         int
+	}{
+		{"no-limit open", 0, 2, 100, 3, NoLimit, 2, 100},
+		{"no-limit raise", 10, 10, 100, 25, NoLimit, 20, 100},
+		{"pot-limit raise", 10, 10, 100, 25, PotLimit, 20, 45},
+		{"pot-limit capped by stack", 10, 10, 30, 25, PotLimit, 20, 30},
+		{"fixed-limit", 10, 10, 100, 25, FixedLimit, 20, 20},
+		{"short stack goes all in", 10, 10, 15, 25, NoLimit, 15, 15},
+	}
+	for _, tt := range tests {
+		min, max := LegalBetSizes(tt.currentBet, tt.minRaise, tt.stack, tt.pot, tt.structure)
+		if min != tt.min || max != tt.max {
+			t.Errorf("%s: LegalBetSizes = %d, %d, want %d, %d", tt.name, min, max, tt.min, tt.max)
+		}
+	}
+}
+
+func TestLegalBetSizesPanics(t *testing.T) {
+	for _, args := range []struct {
+		minRaise  int
+		structure string
+	}{{2, "spread-limit"}, {0, NoLimit}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("LegalBetSizes(minRaise %d, %q) did not panic", args.minRaise, args.structure)
+				}
+			}()
+			LegalBetSizes(0, args.minRaise, 100, 0, args.structure)
+		}()
+	}
+}
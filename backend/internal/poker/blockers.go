@@ -0,0 +1,64 @@
+package poker
+
+import "time"
+
+// BlockerEffect compares villain's possible holdings, and hero's heads-up
+// equity against them, before and after one extra card is known to be out
+// of villain's hand.
+type BlockerEffect struct {
+	Combos        int     // villain holdings without the blocker
+	BlockedCombos int     // villain holdings once the blocker is removed
+	Equity        float64 // hero equity (0-1, ties count half) vs Combos
+	BlockedEquity float64 // hero equity vs BlockedCombos
+
+	// On a complete board, the number of villain holdings making each hand
+	// category, indexed by category. Nil otherwise.
+	CategoryCombos        []int
+	BlockedCategoryCombos []int
+}
+
+// CardRemovalEffect measures the blocker effect of a single card: villain
+// may hold any two cards not in hole or community, or additionally not the
+// blocker. The blocker only leaves villain's range; it can still come on
+// the board. Both equities are simulated with the same seed (zero picks one
+// from the clock) so the difference is not swamped by sampling noise.
+func CardRemovalEffect(hole, community []Card, blocker Card, trials int, seed int64) BlockerEffect {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	if HasDuplicates(append(append([]Card{blocker}, hole...), community...)) {
+		panic("blocker must not be one of the known cards")
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	known := append(append([]Card{}, hole...), community...)
+	open := RemainingHoldings(known)
+	blocked := RemainingHoldings(append(known, blocker))
+
+	equity := func(villainRange [][2]Card) float64 {
+		res := SimulateEquityWithOptions(hole, community, 1, trials, SimulationOptions{VillainRange: villainRange, Seed: seed})
+		heroWin, _, tie := res.Rates()
+		return heroWin + tie/2
+	}
+	e := BlockerEffect{
+		Combos:        len(open),
+		BlockedCombos: len(blocked),
+		Equity:        equity(open),
+		BlockedEquity: equity(blocked),
+	}
+	if len(community) == 5 {
+		e.CategoryCombos = categoryCounts(open, community)
+		e.BlockedCategoryCombos = categoryCounts(blocked, community)
+	}
+	return e
+}
+
+func categoryCounts(holdings [][2]Card, board []Card) []int {
+	counts := make([]int, StraightFlush+1)
+	for _, h := range holdings {
+		counts[Holdem.BestHand(h[:], board).Category]++
+	}
+	return counts
+}
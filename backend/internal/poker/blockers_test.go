@@ -0,0 +1,36 @@
+package poker
+
+import "testing"
+
+func TestCardRemovalEffect(t *testing.T) {
+	hole := mustCards(t, "Ah", "Kd")
+	board := mustCards(t, "2c", "7d", "9h", "Js", "4c")
+	e := CardRemovalEffect(hole, board, mustCards(t, "Qs")[0], 2000, 1)
+	if e.Combos != 990 || e.BlockedCombos != 946 {
+		t.Errorf("combos %d -> %d, want 990 -> 946", e.Combos, e.BlockedCombos)
+	}
+	sum := func(xs []int) int {
+		n := 0
+		for _, x := range xs {
+			n += x
+		}
+		return n
+	}
+	if sum(e.CategoryCombos) != e.Combos || sum(e.BlockedCategoryCombos) != e.BlockedCombos {
+		t.Errorf("category counts %v, %v", e.CategoryCombos, e.BlockedCategoryCombos)
+	}
+
+	flop := CardRemovalEffect(hole, board[:3], mustCards(t, "Qs")[0], 2000, 1)
+	if flop.CategoryCombos != nil {
+		t.Error("category counts on the flop")
+	}
+}
+
+func TestCardRemovalEffectPanicsOnKnownBlocker(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic")
+		}
+	}()
+	CardRemovalEffect(mustCards(t, "Ah", "Kd"), nil, mustCards(t, "Ah")[0], 100, 1)
+}
@@ -0,0 +1,73 @@
+package poker
+
+// BluffCatchEV returns the expected chip gain of calling a river bet of
+// betSize into potBefore (the pot before the bet) with a bluff catcher.
+// bluffFrequency (0-1) is the share of villain's betting range that is a
+// bluff; hero wins against bluffs with probability heroEquityVsBluffs (0-1)
+// and is assumed to lose to every value bet. Winning collects potBefore plus
+// villain's bet; losing costs the call.
+//
+// With heroEquityVsBluffs = 1 the EV is zero exactly at
+// IndifferenceBluffFrequency(potBefore, betSize).
+func BluffCatchEV(potBefore, betSize, heroEquityVsBluffs, bluffFrequency float64) float64 {
+	winVsBluff := heroEquityVsBluffs*(potBefore+betSize) - (1-heroEquityVsBluffs)*betSize
+	return bluffFrequency*winVsBluff - (1-bluffFrequency)*betSize
+}
+
+// IndifferenceBluffFrequency returns the bluff frequency at which calling
+// betSize into potBefore with a pure bluff catcher breaks even:
+// betSize / (potBefore + 2*betSize), i.e. the call's pot odds. It is the
+// bluffing rate that makes the caller indifferent, the counterpart of
+// MinDefenseFrequency.
+func IndifferenceBluffFrequency(potBefore, betSize float64) float64 {
+	if potBefore+2*betSize <= 0 {
+		return 0
+	}
+	return betSize / (potBefore + 2*betSize)
+}
+
+// MinDefenseFrequency returns the share (0-1) of hands a player must
+// continue with against a bet of bet into pot so that a pure bluff cannot
+// profit: pot / (pot + bet). With no bet (or no chips at stake at all) there
+// is nothing to fold to and it returns 1; with an empty pot it returns 0.
+func MinDefenseFrequency(pot, bet float64) float64 {
+	if bet <= 0 {
+		return 1
+	}
+	if pot <= 0 {
+		return 0
+	}
+	return pot / (pot + bet)
+}
+
+// BetCandidate is a bet size under consideration together with how often
+// it is expected to make villain fold.
+type BetCandidate struct {
+	Size            float64
+	FoldProbability float64 // 0-1
+	EV              float64 // filled in by BestBetSize
+}
+
+// BetEV returns the expected chip gain of betting betSize into pot: villain
+// folds with probability foldProbability and hero takes the pot; otherwise
+// villain calls and hero wins the pot plus both bets with probability
+// equity (0-1), having risked betSize. A size of 0 is a check that always
+// sees a showdown.
+func BetEV(pot, equity, betSize, foldProbability float64) float64 {
+	called := equity*(pot+2*betSize) - betSize
+	return foldProbability*pot + (1-foldProbability)*called
+}
+
+// BestBetSize evaluates BetEV for every candidate and returns the one with
+// the highest EV, with its EV set; the first wins ties. It returns the zero
+// BetCandidate if there are no candidates.
+func BestBetSize(pot, equity float64, candidates []BetCandidate) BetCandidate {
+	var best BetCandidate
+	for i, c := range candidates {
+		c.EV = BetEV(pot, equity, c.Size, c.FoldProbability)
+		if i == 0 || c.EV > best.EV {
+			best = c
+		}
+	}
+	return best
+}
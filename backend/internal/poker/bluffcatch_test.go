@@ -0,0 +1,70 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBluffCatchEV(t *testing.T) {
+	tests := []struct {
+		name                       string
+		pot, bet, equity, bluffing float64
+		want                       float64
+	}{
+		{"indifferent", 100, 50, 1, IndifferenceBluffFrequency(100, 50), 0},
+		{"never bluffing", 100, 50, 1, 0, -50},
+		{"always bluffing", 100, 50, 1, 1, 150},
+		{"half the bluffs beat us", 100, 50, 0.5, 1, 50},
+	}
+	for _, tt := range tests {
+		if got := BluffCatchEV(tt.pot, tt.bet, tt.equity, tt.bluffing); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: BluffCatchEV = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIndifferenceBluffFrequency(t *testing.T) {
+	tests := []struct {
+		pot, bet, want float64
+	}{
+		{100, 50, 0.25},
+		{100, 100, 1.0 / 3},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := IndifferenceBluffFrequency(tt.pot, tt.bet); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("IndifferenceBluffFrequency(%v, %v) = %v, want %v", tt.pot, tt.bet, got, tt.want)
+		}
+	}
+}
+
+func TestMinDefenseFrequency(t *testing.T) {
+	tests := []struct {
+		pot, bet, want float64
+	}{
+		{100, 50, 2.0 / 3},
+		{100, 100, 0.5},
+		{100, 0, 1},
+		{0, 0, 1},
+		{0, 50, 0},
+	}
+	for _, tt := range tests {
+		if got := MinDefenseFrequency(tt.pot, tt.bet); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("MinDefenseFrequency(%v, %v) = %v, want %v", tt.pot, tt.bet, got, tt.want)
+		}
+	}
+}
+
+func TestBestBetSize(t *testing.T) {
+	candidates := []BetCandidate{{Size: 50, FoldProbability: 0.5}, {Size: 100, FoldProbability: 0.2}}
+	got := BestBetSize(100, 0.3, candidates)
+	if got.Size != 50 || math.Abs(got.EV-55) > 1e-9 {
+		t.Errorf("BestBetSize = %+v, want size 50 with EV 55", got)
+	}
+	if got := BestBetSize(100, 0.3, nil); got != (BetCandidate{}) {
+		t.Errorf("no candidates: %+v", got)
+	}
+	if got := BetEV(100, 0.5, 0, 0); got != 50 {
+		t.Errorf("check: BetEV = %v, want 50", got)
+	}
+}
@@ -2,9 +2,12 @@ package poker
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // Card is represented as a 2-character string, e.g. "HA", "S7", "CT".
+// ParseCard also accepts rank-first notation such as "Ah" or "10c".
 // Suits: H (hearts), D (diamonds), C (clubs), S (spades)
 // Ranks: 2-9, T (10), J, Q, K, A
 
@@ -37,62 +40,132 @@ const (
 type Card struct {
 	Suit Suit
 	Rank Rank
-	Str  string // original string ("HA", etc.) for convenience
+	Str  string // canonical suit-first string ("HA", etc.) for convenience
 }
 
-// ParseCard converts a 2-character string like "HA" into a Card.
+// ParseCard converts a card string into a Card. Two notations are accepted:
+//
+//	suit-first: "HA", "S7", "CT", "H10" (the canonical form uses "T")
+//	rank-first: "Ah", "Td", "10c" (suit may be lower or upper case)
+//
+// The form is detected by checking whether the first character is a suit.
+// The returned Card's Str is always in the canonical suit-first form.
+// Wildcards are rejected; see ParseCardOrWild.
 func ParseCard(s string) (Card, error) {
-	if len(s) != 2 {
+	if len(s) < 2 || len(s) > 3 {
 		return Card{}, fmt.Errorf("invalid card format: %s", s)
 	}
 
-	var r Rank
-	switch s[1] {
+	var suitStr, rankStr string
+	if _, ok := parseSuit(s[0]); ok {
+		suitStr, rankStr = s[:1], s[1:]
+	} else {
+		rankStr, suitStr = s[:len(s)-1], s[len(s)-1:]
+	}
+
+	r, ok := parseRank(rankStr)
+	if !ok {
+		return Card{}, fmt.Errorf("invalid rank: %s", rankStr)
+	}
+	suit, ok := parseSuit(suitStr[0])
+	if !ok {
+		return Card{}, fmt.Errorf("invalid suit: %s", suitStr)
+	}
+
+	c := Card{Suit: suit, Rank: r}
+	c.Normalize()
+	return c, nil
+}
+
+// MarshalText encodes c as its canonical string ("HA"), so Cards appear as
+// plain strings in JSON.
+func (c Card) MarshalText() ([]byte, error) {
+	if c.IsWild() {
+		return []byte(WildCard.Str), nil
+	}
+	return []byte(formatCard(c.Suit, c.Rank)), nil
+}
+
+// UnmarshalText parses any notation ParseCard accepts.
+func (c *Card) UnmarshalText(text []byte) error {
+	parsed, err := ParseCard(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Normalize recomputes Str from Suit and Rank so that it is always in the
+// canonical suit-first form ("HT" rather than "H10" or "Th").
+func (c *Card) Normalize() {
+	c.Str = formatCard(c.Suit, c.Rank)
+}
+
+// index returns a value in [0, 52) uniquely identifying the card by
+// suit and rank. Use it instead of Str when deduplicating cards. WildCard
+// has no index; it panics rather than alias a real card.
+func (c Card) index() int {
+	if c.Rank < Two {
+		panic(fmt.Sprintf("card %q has no index", c.Str))
+	}
+	return int(c.Suit)*13 + int(c.Rank-Two)
+}
+
+func parseRank(s string) (Rank, bool) {
+	if s == "10" {
+		return Ten, true
+	}
+	if len(s) != 1 {
+		return 0, false
+	}
+	switch s[0] {
 	case '2':
-		r = Two
+		return Two, true
 	case '3':
-		r = Three
+		return Three, true
 	case '4':
-		r = Four
+		return Four, true
 	case '5':
-		r = Five
+		return Five, true
 	case '6':
-		r = Six
+		return Six, true
 	case '7':
-		r = Seven
+		return Seven, true
 	case '8':
-		r = Eight
+		return Eight, true
 	case '9':
-		r = Nine
+		return Nine, true
 	case 'T':
-		r = Ten
+		return Ten, true
 	case 'J':
-		r = Jack
+		return Jack, true
 	case 'Q':
-		r = Queen
+		return Queen, true
 	case 'K':
-		r = King
+		return King, true
 	case 'A':
-		r = Ace
-	default:
-		return Card{}, fmt.Errorf("invalid rank: %c", s[1])
+		return Ace, true
 	}
+	return 0, false
+}
 
-	var suit Suit
-	switch s[0] {
-	case 'H':
-		suit = Hearts
-	case 'D':
-		suit = Diamonds
-	case 'C':
-		suit = Clubs
-	case 'S':
-		suit = Spades
-	default:
-		return Card{}, fmt.Errorf("invalid suit: %c", s[0])
+func parseSuit(b byte) (Suit, bool) {
+	switch b {
+	case 'H', 'h':
+		return Hearts, true
+	case 'D', 'd':
+		return Diamonds, true
+	case 'C', 'c':
+		return Clubs, true
+	case 'S', 's':
+		return Spades, true
 	}
+	return 0, false
+}
 
-	return Card{Suit: suit, Rank: r, Str: s}, nil
+func newCard(s Suit, r Rank) Card {
+	return Card{Suit: s, Rank: r, Str: formatCard(s, r)}
 }
 
 // FullDeck returns all 52 cards.
@@ -103,59 +176,106 @@ func FullDeck() []Card {
 	deck := make([]Card, 0, 52)
 	for _, s := range suits {
 		for _, r := range ranks {
-			deck = append(deck, Card{
-				Suit: s,
-				Rank: r,
-				Str:  formatCard(s, r),
-			})
+			deck = append(deck, newCard(s, r))
 		}
 	}
 	return deck
 }
 
+// HasDuplicates reports whether the same card appears more than once.
+// Wildcards are skipped, since any number of them may stand for different
+// cards.
+func HasDuplicates(cards []Card) bool {
+	var seen [52]bool
+	for _, c := range cards {
+		if c.IsWild() {
+			continue
+		}
+		if seen[c.index()] {
+			return true
+		}
+		seen[c.index()] = true
+	}
+	return false
+}
+
+// SortCards sorts cards in place into canonical order: rank descending
+// (Ace first), then suit in declaration order (Hearts, Diamonds, Clubs,
+// Spades).
+func SortCards(cards []Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		if cards[i].Rank != cards[j].Rank {
+			return cards[i].Rank > cards[j].Rank
+		}
+		return cards[i].Suit < cards[j].Suit
+	})
+}
+
+// CanonicalKey returns a stable string key for a set of cards, independent
+// of their order and of the Str field, e.g. "SA,HK,D2". The input is not
+// modified.
+func CanonicalKey(cards []Card) string {
+	sorted := append([]Card(nil), cards...)
+	SortCards(sorted)
+	parts := make([]string, len(sorted))
+	for i, c := range sorted {
+		parts[i] = formatCard(c.Suit, c.Rank)
+	}
+	return strings.Join(parts, ",")
+}
+
 func formatCard(s Suit, r Rank) string {
-	var suitChar byte
+	return string([]byte{suitChar(s), rankChar(r)})
+}
+
+func suitChar(s Suit) byte {
 	switch s {
 	case Hearts:
-		suitChar = 'H'
+		return 'H'
 	case Diamonds:
-		suitChar = 'D'
+		return 'D'
 	case Clubs:
-		suitChar = 'C'
+		return 'C'
 	case Spades:
-		suitChar = 'S'
+		return 'S'
 	}
+	return '?'
+}
+
+// String returns the rank's card character: "2" to "9", "T", "J", "Q",
+// "K" or "A".
+func (r Rank) String() string {
+	return string(rankChar(r))
+}
 
-	var rankChar byte
+func rankChar(r Rank) byte {
 	switch r {
 	case Two:
-		rankChar = '2'
+		return '2'
 	case Three:
-		rankChar = '3'
+		return '3'
 	case Four:
-		rankChar = '4'
+		return '4'
 	case Five:
-		rankChar = '5'
+		return '5'
 	case Six:
-		rankChar = '6'
+		return '6'
 	case Seven:
-		rankChar = '7'
+		return '7'
 	case Eight:
-		rankChar = '8'
+		return '8'
 	case Nine:
-		rankChar = '9'
+		return '9'
 	case Ten:
-		rankChar = 'T'
+		return 'T'
 	case Jack:
-		rankChar = 'J'
+		return 'J'
 	case Queen:
-		rankChar = 'Q'
+		return 'Q'
 	case King:
-		rankChar = 'K'
+		return 'K'
 	case Ace:
-		rankChar = 'A'
+		return 'A'
 	}
-
-	return string([]byte{suitChar, rankChar})
+	return '?'
 }
-
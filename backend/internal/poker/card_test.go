@@ -0,0 +1,141 @@
+package poker
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Card
+	}{
+		{"HA", newCard(Hearts, Ace)},
+		{"S7", newCard(Spades, Seven)},
+		{"CT", newCard(Clubs, Ten)},
+		{"H10", newCard(Hearts, Ten)},
+		{"Ah", newCard(Hearts, Ace)},
+		{"Td", newCard(Diamonds, Ten)},
+		{"10c", newCard(Clubs, Ten)},
+		{"2S", newCard(Spades, Two)},
+	}
+	for _, tt := range tests {
+		got, err := ParseCard(tt.in)
+		if err != nil {
+			t.Errorf("ParseCard(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCard(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseCardRejects(t *testing.T) {
+	for _, in := range []string{"", "A", "?", "Xh", "H1", "Ax", "HAA", "11h", "10"} {
+		if c, err := ParseCard(in); err == nil {
+			t.Errorf("ParseCard(%q) = %+v, want error", in, c)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	c := Card{Suit: Diamonds, Rank: Ten, Str: "D10"}
+	c.Normalize()
+	if c.Str != "DT" {
+		t.Errorf("Normalize: Str = %q, want DT", c.Str)
+	}
+}
+
+func TestHasDuplicatesIgnoresStr(t *testing.T) {
+	a := newCard(Hearts, Ten)
+	b := Card{Suit: Hearts, Rank: Ten, Str: "H10"}
+	tests := []struct {
+		cards []Card
+		want  bool
+	}{
+		{nil, false},
+		{[]Card{a}, false},
+		{[]Card{a, newCard(Spades, Ten)}, false},
+		{[]Card{a, b}, true},
+		{FullDeck(), false},
+	}
+	for _, tt := range tests {
+		if got := HasDuplicates(tt.cards); got != tt.want {
+			t.Errorf("HasDuplicates(%v) = %v, want %v", tt.cards, got, tt.want)
+		}
+	}
+}
+
+func TestFullDeck(t *testing.T) {
+	deck := FullDeck()
+	if len(deck) != 52 {
+		t.Fatalf("len(FullDeck()) = %d", len(deck))
+	}
+	for i, c := range deck {
+		if c.index() != i {
+			t.Errorf("deck[%d] = %v has index %d", i, c, c.index())
+		}
+	}
+}
+
+func TestSortCardsAndCanonicalKey(t *testing.T) {
+	cards := mustCards(t, "2d", "As", "Kh", "Ah", "2c")
+	key := CanonicalKey(cards)
+	if key != "HA,SA,HK,D2,C2" {
+		t.Errorf("CanonicalKey = %q", key)
+	}
+	if cards[0].Str != "D2" {
+		t.Errorf("CanonicalKey modified its input: %v", cards)
+	}
+	reordered := mustCards(t, "Kh", "2c", "Ah", "As", "2d")
+	if got := CanonicalKey(reordered); got != key {
+		t.Errorf("CanonicalKey depends on order: %q vs %q", got, key)
+	}
+	SortCards(cards)
+	if got := cardStrs(cards); got != "HA SA HK D2 C2" {
+		t.Errorf("SortCards = %s", got)
+	}
+}
+
+func TestCardJSON(t *testing.T) {
+	cards := mustCards(t, "Ah", "10c")
+	b, err := json.Marshal(cards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `["HA","CT"]` {
+		t.Errorf("Marshal = %s", b)
+	}
+	var back []Card
+	if err := json.Unmarshal([]byte(`["Ah","CT"]`), &back); err != nil {
+		t.Fatal(err)
+	}
+	if back[0] != cards[0] || back[1] != cards[1] {
+		t.Errorf("Unmarshal = %v", back)
+	}
+	if err := json.Unmarshal([]byte(`["Zz"]`), &back); err == nil {
+		t.Error("Unmarshal accepted an invalid card")
+	}
+}
+
+func TestRankString(t *testing.T) {
+	want := "23456789TJQKA"
+	for r := Two; r <= Ace; r++ {
+		if got := r.String(); got != want[r-Two:r-Two+1] {
+			t.Errorf("Rank(%d).String() = %q", r, got)
+		}
+	}
+}
+
+// cardStrs joins the canonical strings of cards with spaces.
+func cardStrs(cards []Card) string {
+	s := ""
+	for i, c := range cards {
+		if i > 0 {
+			s += " "
+		}
+		s += c.Str
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package poker
+
+import (
+	"math"
+	"math/rand"
+)
+
+// confidenceZ is the normal quantile for the 95% margins used by
+// SimulationOptions.TargetMarginPct.
+const confidenceZ = 1.96
+
+// confidenceBatch is the number of trials run between margin checks and
+// progress reports. It is a whole number of chunks so that batch k continues
+// the chunk seeds where batch k-1 stopped, and an adaptive run that stops
+// after N trials matches a fixed run of N trials with the same seed.
+const confidenceBatch = 4 * chunkTrials
+
+// SimulateToConfidence is like SimulateEquity but runs until the 95% margin
+// of error on hero's win rate is at most targetMarginPct percentage points
+// (e.g. 1 for ±1%), or maxTrials trials have run. Trials are added in
+// batches, so slightly more may run than the margin strictly needs.
+func SimulateToConfidence(hole, community []Card, numOpponents int, targetMarginPct, maxTrials float64) SimulationResult {
+	if targetMarginPct <= 0 {
+		panic("targetMarginPct must be > 0")
+	}
+	return SimulateEquityWithOptions(hole, community, numOpponents, int(maxTrials), SimulationOptions{TargetMarginPct: targetMarginPct})
+}
+
+// MarginPct returns the 95% margin of error, in percentage points, of the
+// hero win rate reported by Rates, using the binomial standard error
+// sqrt(p(1-p)/n). For importance-sampled runs this is only a rough guide.
+func (r SimulationResult) MarginPct() float64 {
+	if r.TrialsRun == 0 {
+		return math.Inf(1)
+	}
+	p, _, _ := r.Rates()
+	p = math.Min(math.Max(p, 0), 1)
+	return confidenceZ * math.Sqrt(p*(1-p)/float64(r.TrialsRun)) * 100
+}
+
+// StdErrPct returns the binomial standard error, in percentage points, of
+// the hero win rate reported by Rates: sqrt(p(1-p)/n). It is 0 for an
+// exact result and +Inf before any trial has run.
+func (r SimulationResult) StdErrPct() float64 {
+	if r.Method == MethodExact {
+		return 0
+	}
+	return r.MarginPct() / confidenceZ
+}
+
+// HeroWinInterval returns the 95% confidence interval, in percent, for the
+// hero win rate reported by Rates: the point estimate plus or minus
+// MarginPct, clamped to [0, 100]. An exact result's interval is the point
+// itself.
+func (r SimulationResult) HeroWinInterval() (low, high float64) {
+	heroWin, _, _ := r.Rates()
+	margin := r.StdErrPct() * confidenceZ
+	return math.Max(heroWin*100-margin, 0), math.Min(heroWin*100+margin, 100)
+}
+
+// OutcomeVariance simulates hero's hand like SimulateEquity and returns the
+// variance of the per-trial result, scoring a win as 1, a tie as 0.5 and a
+// loss as 0. Hands with the same equity can differ a lot here: a draw that
+// either gets there or not swings more than a made hand that splits often.
+func OutcomeVariance(hole, community []Card, numOpponents, trials int) float64 {
+	return SimulateEquity(hole, community, numOpponents, trials).Variance()
+}
+
+// Variance returns the variance of the per-trial result (win 1, tie 0.5,
+// loss 0) over the trials in r. Without ties it is p(1-p) for the win rate
+// p. Importance-sampling weights are ignored.
+func (r SimulationResult) Variance() float64 {
+	if r.TrialsRun == 0 {
+		return 0
+	}
+	n := float64(r.TrialsRun)
+	mean := (float64(r.HeroWins) + 0.5*float64(r.Ties)) / n
+	meanSq := (float64(r.HeroWins) + 0.25*float64(r.Ties)) / n
+	return meanSq - mean*mean
+}
+
+// runBatched runs work in batches of confidenceBatch trials, reporting
+// each merged result to opts.Progress, until the margin reaches
+// opts.TargetMarginPct, Progress asks to stop, or maxTrials have been dealt.
+func runBatched(seed int64, maxTrials int, opts SimulationOptions, work func(rng *rand.Rand, local *SimulationResult, n int)) SimulationResult {
+	res := SimulationResult{Method: MethodMonteCarlo, Seed: seed}
+	for done := 0; done < maxTrials; {
+		n := min(confidenceBatch, maxTrials-done)
+		res = MergeResults(res, runParallel(seed+int64(done/chunkTrials), n, opts.Workers, opts.NewRNG, work))
+		done += n
+		if opts.Progress != nil && !opts.Progress(res) {
+			break
+		}
+		if opts.TargetMarginPct > 0 && res.TrialsRun > 0 && res.MarginPct() <= opts.TargetMarginPct {
+			break
+		}
+	}
+	res.Samples = res.Samples[:min(len(res.Samples), opts.SampleTrials)]
+	return res
+}
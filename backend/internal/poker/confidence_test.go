@@ -0,0 +1,75 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeroWinIntervalNarrowsWithTrials(t *testing.T) {
+	hole := mustCards(t, "Ah", "Kd")
+	prevWidth := math.Inf(1)
+	for _, trials := range []int{1000, 10000, 100000} {
+		res := SimulateEquityWithSeed(hole, nil, 1, trials, 5)
+		low, high := res.HeroWinInterval()
+		win, _, _ := res.Rates()
+		if low > win*100 || high < win*100 {
+			t.Errorf("%d trials: interval [%v, %v] misses %v", trials, low, high, win*100)
+		}
+		if width := high - low; width >= prevWidth {
+			t.Errorf("%d trials: width %v did not shrink from %v", trials, width, prevWidth)
+		} else {
+			prevWidth = width
+		}
+	}
+
+	exact := EnumerateEquity(hole, mustCards(t, "2c", "7d", "9h", "Js", "4c"))
+	if low, high := exact.HeroWinInterval(); low != high || exact.StdErrPct() != 0 {
+		t.Errorf("exact interval [%v, %v]", low, high)
+	}
+}
+
+func TestSimulateToConfidence(t *testing.T) {
+	hole := mustCards(t, "Ah", "Kd")
+	res := SimulateToConfidence(hole, nil, 1, 1, 1e6)
+	if res.MarginPct() > 1 || res.TrialsRun >= 1e6 {
+		t.Errorf("target 1%%: margin %v after %d trials", res.MarginPct(), res.TrialsRun)
+	}
+	if res := SimulateToConfidence(hole, nil, 1, 0.01, 1000); res.TrialsRun != 1000 {
+		t.Errorf("capped run: %d trials", res.TrialsRun)
+	}
+}
+
+func TestMarginPct(t *testing.T) {
+	if m := (SimulationResult{}).MarginPct(); !math.IsInf(m, 1) {
+		t.Errorf("no trials: MarginPct = %v", m)
+	}
+	r := SimulationResult{HeroWins: 50, VillainWins: 50, TrialsRun: 100}
+	if got, want := r.MarginPct(), 1.96*0.05*100; math.Abs(got-want) > 1e-9 {
+		t.Errorf("MarginPct = %v, want %v", got, want)
+	}
+}
+
+func TestVariance(t *testing.T) {
+	tests := []struct {
+		res  SimulationResult
+		want float64
+	}{
+		{SimulationResult{HeroWins: 2, VillainWins: 2, TrialsRun: 4}, 0.25},
+		{SimulationResult{Ties: 4, TrialsRun: 4}, 0},
+		{SimulationResult{HeroWins: 4, TrialsRun: 4}, 0},
+		{SimulationResult{HeroWins: 1, Ties: 1, TrialsRun: 2}, 0.0625},
+		{SimulationResult{}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.res.Variance(); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Variance(%+v) = %v, want %v", tt.res, got, tt.want)
+		}
+	}
+
+	// A flush draw swings more than a made hand that is rarely outdrawn.
+	draw := OutcomeVariance(mustCards(t, "Ah", "Kh"), mustCards(t, "7h", "2h", "9c", "3s"), 1, 5000)
+	made := OutcomeVariance(mustCards(t, "9d", "9s"), mustCards(t, "7h", "2h", "9c", "3s"), 1, 5000)
+	if draw <= made {
+		t.Errorf("draw variance %v <= made hand variance %v", draw, made)
+	}
+}
@@ -0,0 +1,52 @@
+package poker
+
+// EquityCurve simulates hero's hand against 1 up to maxOpponents random
+// opponents, trials trials each; entry i is the result against i+1
+// opponents.
+func EquityCurve(hole, community []Card, maxOpponents, trials int) []SimulationResult {
+	out := make([]SimulationResult, maxOpponents)
+	for n := 1; n <= maxOpponents; n++ {
+		out[n-1] = SimulateEquity(hole, community, n, trials)
+	}
+	return out
+}
+
+// DefaultBetFractions are the bet sizes, as fractions of the pot, that
+// EquityTable uses when none are given.
+var DefaultBetFractions = []float64{0.25, 0.5, 0.75, 1, 1.5, 2}
+
+// EquityTable is hero's equity by opponent count alongside the call or
+// fold decision it implies for each bet size.
+type EquityTable struct {
+	Equity       []float64  // 0-1, ties count half; entry i is against i+1 opponents
+	BetFractions []float64  // bet sizes as fractions of the pot
+	Actions      [][]string // [bet][opponents-1]: ActionCall or ActionFold
+}
+
+// BuildEquityTable runs EquityCurve and, for every bet of fraction f of
+// the pot, recommends ActionCall where hero's equity covers the pot odds
+// f/(1+2f) and ActionFold otherwise. The pot odds are those of calling a
+// single bettor; callers behind are ignored. Nil betFractions means
+// DefaultBetFractions.
+func BuildEquityTable(hole, community []Card, maxOpponents, trials int, betFractions []float64) EquityTable {
+	if betFractions == nil {
+		betFractions = DefaultBetFractions
+	}
+	t := EquityTable{BetFractions: betFractions}
+	for _, res := range EquityCurve(hole, community, maxOpponents, trials) {
+		heroWin, _, tie := res.Rates()
+		t.Equity = append(t.Equity, heroWin+tie/2)
+	}
+	for _, f := range betFractions {
+		required := f / (1 + 2*f)
+		row := make([]string, len(t.Equity))
+		for i, eq := range t.Equity {
+			row[i] = ActionFold
+			if eq >= required {
+				row[i] = ActionCall
+			}
+		}
+		t.Actions = append(t.Actions, row)
+	}
+	return t
+}
@@ -0,0 +1,36 @@
+package poker
+
+import "testing"
+
+func TestEquityCurve(t *testing.T) {
+	curve := EquityCurve(mustCards(t, "Ah", "Ad"), nil, 5, 3000)
+	if len(curve) != 5 {
+		t.Fatalf("len(EquityCurve) = %d, want 5", len(curve))
+	}
+	first, _, _ := curve[0].Rates()
+	last, _, _ := curve[4].Rates()
+	if last >= first {
+		t.Errorf("AA wins %v against one opponent and %v against five", first, last)
+	}
+}
+
+func TestBuildEquityTable(t *testing.T) {
+	// A royal flush calls everything.
+	table := BuildEquityTable(mustCards(t, "Ah", "Kh"), mustCards(t, "Qh", "Jh", "Th", "2c", "3d"), 3, 200, nil)
+	if len(table.Equity) != 3 || len(table.Actions) != len(DefaultBetFractions) {
+		t.Fatalf("table has %d equities and %d rows", len(table.Equity), len(table.Actions))
+	}
+	for i, row := range table.Actions {
+		for j, action := range row {
+			if action != ActionCall {
+				t.Errorf("bet %v vs %d opponents: %s", table.BetFractions[i], j+1, action)
+			}
+		}
+	}
+
+	// 72o on a board it cannot win folds to a pot-sized bet.
+	table = BuildEquityTable(mustCards(t, "7c", "2d"), mustCards(t, "Ah", "Kh", "Qh", "Jh", "9s"), 1, 500, []float64{1})
+	if table.Actions[0][0] != ActionFold {
+		t.Errorf("72o facing a pot bet: %s with equity %v", table.Actions[0][0], table.Equity[0])
+	}
+}
@@ -0,0 +1,107 @@
+package poker
+
+// String returns DescribeHand(hv), so hand values print readably in logs.
+func (hv HandValue) String() string {
+	return DescribeHand(hv)
+}
+
+// CategoryName returns the display name of a hand category, such as
+// "Full House" or "One Pair".
+func CategoryName(cat int) string {
+	switch cat {
+	case StraightFlush:
+		return "Straight Flush"
+	case FourOfAKind:
+		return "Four of a Kind"
+	case FullHouse:
+		return "Full House"
+	case Flush:
+		return "Flush"
+	case Straight:
+		return "Straight"
+	case ThreeOfAKind:
+		return "Three of a Kind"
+	case TwoPair:
+		return "Two Pair"
+	case OnePair:
+		return "One Pair"
+	default:
+		return "High Card"
+	}
+}
+
+// DescribeHand returns a human-readable description of a hand value, such
+// as "Full House, Aces full of Sevens", "Two Pair, Kings and Tens" or
+// "Straight, Five high" for the wheel.
+func DescribeHand(hv HandValue) string {
+	k := hv.Kickers
+	at := func(i int) Rank {
+		if i < len(k) {
+			return k[i]
+		}
+		return Two
+	}
+
+	switch hv.Category {
+	case StraightFlush:
+		if at(0) == Ace {
+			return "Royal Flush"
+		}
+		return "Straight Flush, " + rankName(at(0)) + " high"
+	case FourOfAKind:
+		return "Four of a Kind, " + rankPlural(at(0))
+	case FullHouse:
+		return "Full House, " + rankPlural(at(0)) + " full of " + rankPlural(at(1))
+	case Flush:
+		return "Flush, " + rankName(at(0)) + " high"
+	case Straight:
+		return "Straight, " + rankName(at(0)) + " high"
+	case ThreeOfAKind:
+		return "Three of a Kind, " + rankPlural(at(0))
+	case TwoPair:
+		return "Two Pair, " + rankPlural(at(0)) + " and " + rankPlural(at(1))
+	case OnePair:
+		return "Pair of " + rankPlural(at(0))
+	default:
+		return rankName(at(0)) + " high"
+	}
+}
+
+func rankName(r Rank) string {
+	switch r {
+	case Two:
+		return "Two"
+	case Three:
+		return "Three"
+	case Four:
+		return "Four"
+	case Five:
+		return "Five"
+	case Six:
+		return "Six"
+	case Seven:
+		return "Seven"
+	case Eight:
+		return "Eight"
+	case Nine:
+		return "Nine"
+	case Ten:
+		return "Ten"
+	case Jack:
+		return "Jack"
+	case Queen:
+		return "Queen"
+	case King:
+		return "King"
+	case Ace:
+		return "Ace"
+	}
+	return "?"
+}
+
+func rankPlural(r Rank) string {
+	if r == Six {
+		return "Sixes"
+	}
+	return rankName(r) + "s"
+}
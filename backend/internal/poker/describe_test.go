@@ -0,0 +1,39 @@
+package poker
+
+import "testing"
+
+func TestDescribeHand(t *testing.T) {
+	tests := []struct {
+		cards []string
+		want  string
+	}{
+		{[]string{"Ah", "Kh", "Qh", "Jh", "Th"}, "Royal Flush"},
+		{[]string{"9h", "8h", "7h", "6h", "5h"}, "Straight Flush, Nine high"},
+		{[]string{"6c", "6d", "6h", "6s", "2c"}, "Four of a Kind, Sixes"},
+		{[]string{"Ac", "Ad", "Ah", "7s", "7c"}, "Full House, Aces full of Sevens"},
+		{[]string{"Kh", "9h", "7h", "4h", "2h"}, "Flush, King high"},
+		{[]string{"Ac", "2d", "3h", "4s", "5c"}, "Straight, Five high"},
+		{[]string{"Qc", "Qd", "Qh", "4s", "2c"}, "Three of a Kind, Queens"},
+		{[]string{"Kc", "Kd", "Th", "Ts", "2c"}, "Two Pair, Kings and Tens"},
+		{[]string{"Jc", "Jd", "8h", "4s", "2c"}, "Pair of Jacks"},
+		{[]string{"Ac", "Jd", "8h", "4s", "2c"}, "Ace high"},
+	}
+	for _, tt := range tests {
+		hv := EvaluateBestHand(mustCards(t, tt.cards...))
+		if got := DescribeHand(hv); got != tt.want {
+			t.Errorf("DescribeHand(%v) = %q, want %q", tt.cards, got, tt.want)
+		}
+		if got := hv.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCategoryName(t *testing.T) {
+	want := []string{"High Card", "One Pair", "Two Pair", "Three of a Kind", "Straight", "Flush", "Full House", "Four of a Kind", "Straight Flush"}
+	for cat, name := range want {
+		if got := CategoryName(cat); got != name {
+			t.Errorf("CategoryName(%d) = %q, want %q", cat, got, name)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package poker
+
+// CombinedDrawEquity returns the probability (0-1) that hero's final hand by
+// the river falls into at least one of the target categories (e.g.
+// []int{Flush, Straight}). Every remaining runout is enumerated once, so
+// runouts completing several targets are not double counted. A straight
+// flush counts as making both a Flush and a Straight target.
+//
+// hole: exactly 2 cards
+// community: 3, 4, or 5 cards
+func CombinedDrawEquity(hole, community []Card, targets []int) float64 {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	if len(community) < 3 || len(community) > 5 {
+		panic("community must be 3, 4, or 5 cards")
+	}
+
+	hits, total := 0, 0
+	forEachRunout(hole, community, func(board []Card) {
+		hv := Holdem.BestHand(hole, board)
+		if hitsTarget(hv.Category, targets) {
+			hits++
+		}
+		total++
+	})
+	return float64(hits) / float64(total)
+}
+
+func hitsTarget(cat int, targets []int) bool {
+	for _, t := range targets {
+		if cat == t {
+			return true
+		}
+		if cat == StraightFlush && (t == Flush || t == Straight) {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachRunout calls fn with every complete 5-card board that extends
+// community using cards not in hole or community. The board slice is reused
+// between calls.
+func forEachRunout(hole, community []Card, fn func(board []Card)) {
+	deck := remainingDeck(hole, community)
+	board := make([]Card, 5)
+	copy(board, community)
+
+	var rec func(pos, start int)
+	rec = func(pos, start int) {
+		if pos == 5 {
+			fn(board)
+			return
+		}
+		for i := start; i < len(deck); i++ {
+			board[pos] = deck[i]
+			rec(pos+1, i+1)
+		}
+	}
+	rec(len(community), 0)
+}
+
+// FlushDrawOuts returns, in deck order, the unseen cards that would give
+// hero a flush on the next card. Hero must hold four cards of a suit among
+// hole and community, at least one of them in hole; otherwise (including
+// when hero already has a flush or the board is complete) it returns nil.
+func FlushDrawOuts(hole, community []Card) []Card {
+	suit, ok := flushDrawSuit(hole, community)
+	if !ok {
+		return nil
+	}
+	var outs []Card
+	for _, c := range remainingDeck(hole, community) {
+		if c.Suit == suit {
+			outs = append(outs, c)
+		}
+	}
+	return outs
+}
+
+// ReverseImpliedRisk estimates how often hero's straight or flush draw
+// completes into a hand that is still second-best. Heuristic: among the
+// Outs that lift hero to a Straight or a Flush on the next card, it returns
+// the fraction (0-1) after which some opponent holding makes a better hand
+// of the same category, e.g. a higher flush over hero's low flush. Better
+// hands of other categories (a full house on a paired board) are ignored,
+// since they threaten any draw alike. It returns 0 if hero has no such
+// outs.
+func ReverseImpliedRisk(hole, community []Card) float64 {
+	dominated, draws := 0, 0
+	for _, out := range Outs(hole, community) {
+		board := append(append([]Card{}, community...), out)
+		hv := EvaluateBestHand(append(append([]Card{}, hole...), board...))
+		if hv.Category != Straight && hv.Category != Flush {
+			continue
+		}
+		draws++
+		for _, cand := range RemainingHoldings(append(append([]Card{}, hole...), board...)) {
+			v := EvaluateBestHand(append(cand[:], board...))
+			if v.Category == hv.Category && CompareHandValues(v, hv) > 0 {
+				dominated++
+				break
+			}
+		}
+	}
+	if draws == 0 {
+		return 0
+	}
+	return float64(dominated) / float64(draws)
+}
+
+// NutFlushOuts returns the subset of FlushDrawOuts after which hero holds
+// the highest card of the suit that is not on the board, i.e. makes the nut
+// flush. Straight flushes are not considered.
+func NutFlushOuts(hole, community []Card) []Card {
+	var nut []Card
+	for _, out := range FlushDrawOuts(hole, community) {
+		var onBoard [Ace + 1]bool
+		for _, c := range append(append([]Card{}, community...), out) {
+			if c.Suit == out.Suit {
+				onBoard[c.Rank] = true
+			}
+		}
+		top := Ace
+		for onBoard[top] {
+			top--
+		}
+		for _, c := range hole {
+			if c.Suit == out.Suit && c.Rank == top {
+				nut = append(nut, out)
+				break
+			}
+		}
+	}
+	return nut
+}
+
+// flushDrawSuit reports the suit in which hero has exactly four cards, at
+// least one of them in hole, with a card still to come.
+func flushDrawSuit(hole, community []Card) (Suit, bool) {
+	if len(community) >= 5 {
+		return 0, false
+	}
+	var counts, holeCounts [4]int
+	for _, c := range hole {
+		counts[c.Suit]++
+		holeCounts[c.Suit]++
+	}
+	for _, c := range community {
+		counts[c.Suit]++
+	}
+	for s := range counts {
+		if counts[s] >= 5 {
+			return 0, false
+		}
+	}
+	for s := range counts {
+		if counts[s] == 4 && holeCounts[s] > 0 {
+			return Suit(s), true
+		}
+	}
+	return 0, false
+}
+
+// Straight draw types returned by StraightDrawType.
+const (
+	StraightDrawNone          = "none"
+	StraightDrawGutshot       = "gutshot"
+	StraightDrawDoubleGutshot = "double gutshot"
+	StraightDrawOpenEnded     = "open-ended"
+	StraightDrawMade          = "straight"
+)
+
+// StraightDrawType classifies the straight draw in a set of ranks and
+// returns its number of straight outs, four per rank that completes a
+// straight, without regard to which cards are already seen. JT98 is
+// open-ended (Q or 7, 8 outs), J987 a gutshot (T, 4 outs) and 97653 a
+// double gutshot (8 or 4, 8 outs); AKQJ and A234 are gutshots as only one
+// end is open. Ranks that already make a straight
+// return StraightDrawMade and 0 outs.
+func StraightDrawType(ranks []Rank) (string, int) {
+	var mask uint16
+	for _, r := range ranks {
+		mask |= 1 << r
+	}
+	if _, ok := straightTop(mask); ok {
+		return StraightDrawMade, 0
+	}
+
+	var outMask uint16
+	outRanks := 0
+	for r := Two; r <= Ace; r++ {
+		if mask&(1<<r) != 0 {
+			continue
+		}
+		if _, ok := straightTop(mask | 1<<r); ok {
+			outMask |= 1 << r
+			outRanks++
+		}
+	}
+	outs := 4 * outRanks
+
+	switch {
+	case outRanks == 0:
+		return StraightDrawNone, 0
+	case outRanks == 1:
+		return StraightDrawGutshot, outs
+	}
+
+	// Bit 1 stands for the ace played low, so A234 is a run like any other.
+	held, open := mask, outMask
+	if mask&(1<<Ace) != 0 {
+		held |= 1 << 1
+	}
+	if outMask&(1<<Ace) != 0 {
+		open |= 1 << 1
+	}
+	for low := Rank(1); low+4 <= Ace; low++ {
+		run := uint16(0xf) << low
+		if held&run == run && open&(1<<(low-1)) != 0 && open&(1<<(low+4)) != 0 {
+			return StraightDrawOpenEnded, outs
+		}
+	}
+	return StraightDrawDoubleGutshot, outs
+}
+
+// Draw types returned by DetectDraws besides the straight draw types.
+const (
+	DrawFlush     = "flush draw"
+	DrawOvercards = "overcards"
+)
+
+// Draw is one of hero's draws: its type (DrawFlush, DrawOvercards or one
+// of the StraightDraw constants), the unseen cards that complete it and
+// their count.
+type Draw struct {
+	Type  string
+	Outs  int
+	Cards []Card
+}
+
+// DetectDraws returns hero's draws on a 3- or 4-card board, in the order
+// flush, straight, overcards. Draws hero has already made are left out:
+// there is no flush draw once hero has a flush, no straight draw once hero
+// has a straight, and overcards only count while hero has no pair. A
+// straight out must give hero a higher straight than the board alone would
+// play, and overcards are hole cards above every board card. Outs shared
+// between draws are listed under each; DrawHitProbability counts them once.
+func DetectDraws(hole, community []Card) []Draw {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	if len(community) != 3 && len(community) != 4 {
+		panic("community must be 3 or 4 cards")
+	}
+
+	deck := remainingDeck(hole, community)
+	var draws []Draw
+	if cards := FlushDrawOuts(hole, community); len(cards) > 0 {
+		draws = append(draws, Draw{Type: DrawFlush, Outs: len(cards), Cards: cards})
+	}
+
+	var heroMask, boardMask uint16
+	for _, c := range hole {
+		heroMask |= 1 << c.Rank
+	}
+	for _, c := range community {
+		heroMask |= 1 << c.Rank
+		boardMask |= 1 << c.Rank
+	}
+	if _, made := straightTop(heroMask); !made {
+		var cards []Card
+		var ranks []Rank
+		for _, c := range deck {
+			heroTop, ok := straightTop(heroMask | 1<<c.Rank)
+			if !ok {
+				continue
+			}
+			if boardTop, _ := straightTop(boardMask | 1<<c.Rank); heroTop > boardTop {
+				cards = append(cards, c)
+			}
+		}
+		for _, c := range append(append([]Card{}, hole...), community...) {
+			ranks = append(ranks, c.Rank)
+		}
+		if len(cards) > 0 {
+			kind, _ := StraightDrawType(ranks)
+			draws = append(draws, Draw{Type: kind, Outs: len(cards), Cards: cards})
+		}
+	}
+
+	if EvaluateBestHand(append(append([]Card{}, hole...), community...)).Category == HighCard {
+		boardTop := Two
+		for _, c := range community {
+			boardTop = max(boardTop, c.Rank)
+		}
+		var cards []Card
+		for _, c := range deck {
+			if (c.Rank == hole[0].Rank || c.Rank == hole[1].Rank) && c.Rank > boardTop {
+				cards = append(cards, c)
+			}
+		}
+		if len(cards) > 0 {
+			draws = append(draws, Draw{Type: DrawOvercards, Outs: len(cards), Cards: cards})
+		}
+	}
+	return draws
+}
+
+// DrawHitProbability returns the probability (0-1) that at least one out
+// of draws arrives by the river on a 3- or 4-card board. Cards listed
+// under several draws count once; board and hole cards are never outs.
+func DrawHitProbability(hole, community []Card, draws []Draw) float64 {
+	var seen [52]bool
+	outs := 0
+	for _, d := range draws {
+		for _, c := range d.Cards {
+			if !seen[c.index()] {
+				seen[c.index()] = true
+				outs++
+			}
+		}
+	}
+
+	unseen := 52 - len(hole) - len(community)
+	miss := 1.0
+	for i := 0; i < 5-len(community); i++ {
+		miss *= float64(unseen-outs-i) / float64(unseen-i)
+	}
+	return 1 - miss
+}
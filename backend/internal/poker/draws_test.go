@@ -0,0 +1,150 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCombinedDrawEquity(t *testing.T) {
+	hole := mustCards(t, "Ah", "Kh")
+	community := mustCards(t, "7h", "2h", "9c")
+
+	// Nine hearts among 47 unseen cards, two to come; no full house is
+	// possible alongside a heart.
+	flush := CombinedDrawEquity(hole, community, []int{Flush})
+	if want := 1 - 703.0/1081.0; math.Abs(flush-want) > 1e-12 {
+		t.Errorf("flush = %v, want %v", flush, want)
+	}
+
+	straight := CombinedDrawEquity(hole, community, []int{Straight})
+	both := CombinedDrawEquity(hole, community, []int{Flush, Straight})
+	if both < flush || both < straight || both > flush+straight {
+		t.Errorf("combined %v not within [max(%v, %v), sum]", both, flush, straight)
+	}
+	if got := CombinedDrawEquity(hole, mustCards(t, "Qh", "Jh", "Th"), []int{Straight}); got != 1 {
+		t.Errorf("royal flush board: straight target = %v, want 1", got)
+	}
+}
+
+func TestFlushDrawOuts(t *testing.T) {
+	tests := []struct {
+		name            string
+		hole, community []string
+		outs, nutOuts   int
+	}{
+		{"nut flush draw", []string{"Ah", "Kh"}, []string{"7h", "2h", "9c"}, 9, 9},
+		{"king is nut with ace on board", []string{"Kh", "Qh"}, []string{"Ah", "2h", "9c"}, 9, 9},
+		{"low flush draw", []string{"5h", "4h"}, []string{"7h", "2h", "9c"}, 9, 0},
+		{"turn flush draw", []string{"Ah", "3c"}, []string{"7h", "2h", "9h", "Kd"}, 9, 9},
+		{"four on board, none in hole", []string{"Ac", "Kd"}, []string{"2h", "5h", "7h", "9h"}, 0, 0},
+		{"made flush", []string{"Ah", "Kh"}, []string{"7h", "2h", "9h"}, 0, 0},
+		{"no draw", []string{"Ah", "Kd"}, []string{"7h", "2c", "9s"}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hole, community := mustCards(t, tt.hole...), mustCards(t, tt.community...)
+			outs := FlushDrawOuts(hole, community)
+			if len(outs) != tt.outs {
+				t.Errorf("FlushDrawOuts = %v, want %d cards", outs, tt.outs)
+			}
+			if nut := NutFlushOuts(hole, community); len(nut) != tt.nutOuts {
+				t.Errorf("NutFlushOuts = %v, want %d cards", nut, tt.nutOuts)
+			}
+		})
+	}
+}
+
+func TestReverseImpliedRisk(t *testing.T) {
+	tests := []struct {
+		hole, community []string
+		want            float64
+	}{
+		{[]string{"3h", "4h"}, []string{"7h", "8h", "Kc"}, 1},
+		{[]string{"Ah", "Kh"}, []string{"7h", "2h", "9c"}, 0},
+		{[]string{"Ac", "Kd"}, []string{"7h", "2s", "9c"}, 0},
+	}
+	for _, tt := range tests {
+		if got := ReverseImpliedRisk(mustCards(t, tt.hole...), mustCards(t, tt.community...)); got != tt.want {
+			t.Errorf("ReverseImpliedRisk(%v, %v) = %v, want %v", tt.hole, tt.community, got, tt.want)
+		}
+	}
+}
+
+func TestStraightDrawType(t *testing.T) {
+	tests := []struct {
+		ranks string
+		kind  string
+		outs  int
+	}{
+		{"JT98", StraightDrawOpenEnded, 8},
+		{"2345", StraightDrawOpenEnded, 8},
+		{"J987", StraightDrawGutshot, 4},
+		{"AKQJ", StraightDrawGutshot, 4},
+		{"A234", StraightDrawGutshot, 4},
+		{"97653", StraightDrawDoubleGutshot, 8},
+		{"65432", StraightDrawMade, 0},
+		{"AK72", StraightDrawNone, 0},
+	}
+	for _, tt := range tests {
+		var ranks []Rank
+		for _, ch := range tt.ranks {
+			r, _ := parseRank(string(ch))
+			ranks = append(ranks, r)
+		}
+		kind, outs := StraightDrawType(ranks)
+		if kind != tt.kind || outs != tt.outs {
+			t.Errorf("StraightDrawType(%s) = %q, %d, want %q, %d", tt.ranks, kind, outs, tt.kind, tt.outs)
+		}
+	}
+}
+
+func TestDetectDraws(t *testing.T) {
+	tests := []struct {
+		name            string
+		hole, community []string
+		types           []string
+		outs            []int
+	}{
+		{"flush draw", []string{"Ah", "3h"}, []string{"7h", "2h", "Ac"}, []string{DrawFlush}, []int{9}},
+		{"gutshot", []string{"9c", "7d"}, []string{"Jh", "Ts", "2c"}, []string{StraightDrawGutshot}, []int{4}},
+		{"open-ended", []string{"9c", "8d"}, []string{"Jh", "Ts", "2c", "2d"}, []string{StraightDrawOpenEnded}, []int{8}},
+		{"flush, gutshot and overcards", []string{"Ah", "Kh"}, []string{"Qh", "Jh", "2c"}, []string{DrawFlush, StraightDrawGutshot, DrawOvercards}, []int{9, 4, 6}},
+		{"made straight", []string{"Ac", "Kd"}, []string{"Qs", "Jh", "Td"}, nil, nil},
+		{"nothing", []string{"2c", "7d"}, []string{"Ks", "Jh", "Td"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draws := DetectDraws(mustCards(t, tt.hole...), mustCards(t, tt.community...))
+			if len(draws) != len(tt.types) {
+				t.Fatalf("got %+v, want types %v", draws, tt.types)
+			}
+			for i, d := range draws {
+				if d.Type != tt.types[i] || d.Outs != tt.outs[i] || len(d.Cards) != d.Outs {
+					t.Errorf("draw %d = %+v, want %s with %d outs", i, d, tt.types[i], tt.outs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDrawHitProbability(t *testing.T) {
+	hole := mustCards(t, "Ah", "Kh")
+	community := mustCards(t, "Qh", "Jh", "2c")
+	draws := DetectDraws(hole, community)
+
+	// Th is both a flush and a straight out: 9 hearts, 3 other tens and 6
+	// aces and kings make 18 distinct outs.
+	want := 1 - 29.0/47.0*28.0/46.0
+	if got := DrawHitProbability(hole, community, draws); math.Abs(got-want) > 1e-12 {
+		t.Errorf("DrawHitProbability = %v, want %v", got, want)
+	}
+
+	turn := append(community, mustCards(t, "3d")...)
+	flush := DetectDraws(hole, turn)[:1]
+	if got, want := DrawHitProbability(hole, turn, flush), 9.0/46.0; math.Abs(got-want) > 1e-12 {
+		t.Errorf("turn flush draw = %v, want %v", got, want)
+	}
+	if got := DrawHitProbability(hole, community, nil); got != 0 {
+		t.Errorf("no draws = %v, want 0", got)
+	}
+}
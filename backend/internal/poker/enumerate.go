@@ -0,0 +1,80 @@
+package poker
+
+// EnumerateEquity computes hero's exact heads-up equity by walking every
+// remaining board runout and every possible villain holding.
+//
+// heroHole: exactly 2 cards
+// community: 4 or 5 cards (earlier streets are too large to enumerate)
+//
+// TrialsRun is set to the number of enumerated (board, villain) combinations.
+func EnumerateEquity(heroHole []Card, community []Card) SimulationResult {
+	if len(heroHole) != 2 {
+		panic("heroHole must have length 2")
+	}
+	if !CanEnumerate(community, 1) {
+		panic("community must be 4 or 5 cards for exact enumeration")
+	}
+
+	deck := remainingDeck(heroHole, community)
+	res := SimulationResult{Method: MethodExact}
+
+	tally := func(board []Card) {
+		heroSeven := append([]Card{}, heroHole...)
+		heroSeven = append(heroSeven, board...)
+		heroBest := EvaluateBestHand(heroSeven)
+
+		known := append(append([]Card{}, heroHole...), board...)
+		for _, opp := range RemainingHoldings(known) {
+			oppSeven := append(opp[:], board...)
+			oppBest := EvaluateBestHand(oppSeven)
+
+			cmp := CompareHandValues(oppBest, heroBest)
+			if cmp > 0 {
+				res.VillainWins++
+			} else if cmp == 0 {
+				res.Ties++
+			} else {
+				res.HeroWins++
+			}
+			res.TrialsRun++
+		}
+	}
+
+	if len(community) == 5 {
+		tally(community)
+		return res
+	}
+
+	for _, river := range deck {
+		board := append([]Card{}, community...)
+		board = append(board, river)
+		tally(board)
+	}
+	return res
+}
+
+// CanEnumerate reports whether EnumerateEquity can be used for the given
+// community cards and number of opponents.
+func CanEnumerate(community []Card, numOpponents int) bool {
+	return numOpponents == 1 && (len(community) == 4 || len(community) == 5)
+}
+
+// remainingDeck returns the full deck minus the given known cards.
+func remainingDeck(known ...[]Card) []Card {
+	// Dedup by suit and rank rather than Str so that cards with a
+	// non-canonical Str are still removed.
+	var used [52]bool
+	for _, cs := range known {
+		for _, c := range cs {
+			used[c.index()] = true
+		}
+	}
+	deck := FullDeck()
+	filtered := make([]Card, 0, len(deck))
+	for _, c := range deck {
+		if !used[c.index()] {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,29 @@
+package poker
+
+import "testing"
+
+func TestEnumerateEquity(t *testing.T) {
+	royal := EnumerateEquity(mustCards(t, "Ah", "Kh"), mustCards(t, "Qh", "Jh", "Th", "2c", "3d"))
+	if royal.Method != MethodExact || royal.TrialsRun != 990 || royal.HeroWins != 990 {
+		t.Errorf("royal flush on the river: %+v", royal)
+	}
+
+	turn := EnumerateEquity(mustCards(t, "Ah", "Kd"), mustCards(t, "2c", "7d", "9h", "Js"))
+	if turn.TrialsRun != 46*990 || turn.HeroWins+turn.VillainWins+turn.Ties != turn.TrialsRun {
+		t.Errorf("turn: %+v", turn)
+	}
+}
+
+func TestCanEnumerate(t *testing.T) {
+	tests := []struct {
+		community, opponents int
+		want                 bool
+	}{
+		{0, 1, false}, {3, 1, false}, {4, 1, true}, {5, 1, true}, {5, 2, false},
+	}
+	for _, tt := range tests {
+		if got := CanEnumerate(FullDeck()[:tt.community], tt.opponents); got != tt.want {
+			t.Errorf("CanEnumerate(%d cards, %d opponents) = %v, want %v", tt.community, tt.opponents, got, tt.want)
+		}
+	}
+}
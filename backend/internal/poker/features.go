@@ -0,0 +1,76 @@
+package poker
+
+// HandFeatures summarises hero's hand on a 3-, 4- or 5-card board as named
+// numeric features for analytics and ML consumers. Flags are 1 or 0. The
+// error is HandPercentile's.
+//
+//	category            current hand category (HighCard = 0 ... StraightFlush = 8)
+//	percentile          HandPercentile of the current hand (0-1)
+//	outs                next cards that improve the category (0 on the river)
+//	flushDraw           hero holds four to a flush, using a hole card
+//	flushDrawOuts       next cards completing that flush
+//	straightDraw        hero has no straight yet but some next card makes one
+//	straightDrawOuts    next cards giving hero a straight, flush or not
+//	boardPaired         two board cards share a rank
+//	boardMaxSuit        the most board cards of one suit
+//	boardStraightWindow the most distinct board ranks in any five-rank window
+//	pocketPair          the hole cards share a rank
+//	suited              the hole cards share a suit
+//	connectedness       Connectedness of the hole cards (-1 for a pair)
+//	highCard            the higher hole card rank (2-14)
+func HandFeatures(hole, community []Card) (map[string]float64, error) {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	if len(community) < 3 || len(community) > 5 {
+		panic("community must be 3, 4, or 5 cards")
+	}
+
+	hv := EvaluateBestHand(append(append([]Card{}, hole...), community...))
+	pct, err := HandPercentile(hv)
+	if err != nil {
+		return nil, err
+	}
+	f := map[string]float64{
+		"category":            float64(hv.Category),
+		"percentile":          pct,
+		"boardPaired":         flag(boardPaired(community)),
+		"boardMaxSuit":        float64(maxSuitCount(community)),
+		"boardStraightWindow": float64(longestStraightWindow(community)),
+		"pocketPair":          flag(hole[0].Rank == hole[1].Rank),
+		"suited":              flag(hole[0].Suit == hole[1].Suit),
+		"connectedness":       float64(Connectedness(hole)),
+		"highCard":            float64(max(hole[0].Rank, hole[1].Rank)),
+	}
+
+	var outs, straightOuts int
+	var flushOuts []Card
+	if len(community) < 5 {
+		outs = len(Outs(hole, community))
+		flushOuts = FlushDrawOuts(hole, community)
+		var mask uint16
+		for _, c := range append(append([]Card{}, hole...), community...) {
+			mask |= 1 << c.Rank
+		}
+		if _, made := straightTop(mask); !made {
+			for _, c := range remainingDeck(hole, community) {
+				if _, ok := straightTop(mask | 1<<c.Rank); ok {
+					straightOuts++
+				}
+			}
+		}
+	}
+	f["outs"] = float64(outs)
+	f["flushDraw"] = flag(len(flushOuts) > 0)
+	f["flushDrawOuts"] = float64(len(flushOuts))
+	f["straightDraw"] = flag(straightOuts > 0)
+	f["straightDrawOuts"] = float64(straightOuts)
+	return f, nil
+}
+
+func flag(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,48 @@
+package poker
+
+import "testing"
+
+func TestHandFeatures(t *testing.T) {
+	f := handFeatures(t, mustCards(t, "Ah", "Kh"), mustCards(t, "7h", "2h", "9c"))
+	want := map[string]float64{
+		"category":            HighCard,
+		"outs":                23,
+		"flushDraw":           1,
+		"flushDrawOuts":       9,
+		"straightDraw":        0,
+		"straightDrawOuts":    0,
+		"boardPaired":         0,
+		"boardMaxSuit":        2,
+		"boardStraightWindow": 2,
+		"pocketPair":          0,
+		"suited":              1,
+		"connectedness":       0,
+		"highCard":            14,
+	}
+	for k, v := range want {
+		if f[k] != v {
+			t.Errorf("%s = %v, want %v", k, f[k], v)
+		}
+	}
+	if p := f["percentile"]; p <= 0 || p >= 1 {
+		t.Errorf("percentile = %v", p)
+	}
+
+	river := handFeatures(t, mustCards(t, "9c", "8d"), mustCards(t, "Jh", "Ts", "2c", "2d", "Kc"))
+	if river["outs"] != 0 || river["straightDraw"] != 0 || river["boardPaired"] != 1 {
+		t.Errorf("river features: %v", river)
+	}
+	turn := handFeatures(t, mustCards(t, "9c", "8d"), mustCards(t, "Jh", "Ts", "2c", "2d"))
+	if turn["straightDraw"] != 1 || turn["straightDrawOuts"] != 8 {
+		t.Errorf("open-ended straight draw: %v", turn)
+	}
+}
+
+func handFeatures(t *testing.T, hole, community []Card) map[string]float64 {
+	t.Helper()
+	f, err := HandFeatures(hole, community)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
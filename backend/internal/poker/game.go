@@ -0,0 +1,119 @@
+package poker
+
+import "fmt"
+
+// Game identifies a poker variant that shares the Hold'em board structure.
+type Game string
+
+const (
+	Holdem Game = "holdem"
+	Omaha  Game = "omaha"
+)
+
+// ParseGame converts a game name into a Game. An empty name means Holdem.
+func ParseGame(s string) (Game, error) {
+	switch Game(s) {
+	case "", Holdem:
+		return Holdem, nil
+	case Omaha:
+		return Omaha, nil
+	}
+	return "", fmt.Errorf("unknown game: %s", s)
+}
+
+// HoleCards returns the number of hole cards dealt to each player.
+func (g Game) HoleCards() int {
+	if g == Omaha {
+		return 4
+	}
+	return 2
+}
+
+// MaxOpponents returns the largest number of opponents a single deck can
+// deal hole cards to while still leaving a full board.
+func (g Game) MaxOpponents() int {
+	return (52-5)/g.HoleCards() - 1
+}
+
+// BestHand returns the best hand a player can make from hole and a
+// complete 5-card board under the rules of the game.
+func (g Game) BestHand(hole, board []Card) HandValue {
+	if g == Omaha {
+		return EvaluateOmahaBestHand(hole, board)
+	}
+	seven := append([]Card{}, hole...)
+	seven = append(seven, board...)
+	return EvaluateBestHand(seven)
+}
+
+// EvaluateOmahaBestHand takes exactly 4 hole cards and 5 community cards and
+// returns the best hand using exactly two hole cards and three board cards.
+func EvaluateOmahaBestHand(hole, board []Card) HandValue {
+	if len(hole) != 4 || len(board) != 5 {
+		panic("EvaluateOmahaBestHand requires 4 hole cards and 5 community cards")
+	}
+
+	var best HandValue
+	first := true
+	for h1 := 0; h1 < 4; h1++ {
+		for h2 := h1 + 1; h2 < 4; h2++ {
+			for b1 := 0; b1 < 5; b1++ {
+				for b2 := b1 + 1; b2 < 5; b2++ {
+					for b3 := b2 + 1; b3 < 5; b3++ {
+						hv := evaluate5([]Card{hole[h1], hole[h2], board[b1], board[b2], board[b3]})
+						if first || CompareHandValues(hv, best) > 0 {
+							best = hv
+							first = false
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+// HandViolation is one way a proposed hand breaks the rules of a game.
+type HandViolation struct {
+	Field   string `json:"field"` // "game", "hole", "community" or "cards"
+	Message string `json:"message"`
+}
+
+// ValidateHand checks a proposed hand, given as card strings, against the
+// rules of the named game: the game must be known, hole must have
+// HoleCards() cards and community 0, 3, 4 or 5, every card must parse (no
+// wildcards), and no card may appear twice. It returns every violation
+// found, or nil if the hand is legal. Card counts are not checked for an
+// unknown game.
+func ValidateHand(game string, hole, community []string) []HandViolation {
+	var out []HandViolation
+	g, err := ParseGame(game)
+	if err != nil {
+		out = append(out, HandViolation{"game", err.Error()})
+	} else if len(hole) != g.HoleCards() {
+		out = append(out, HandViolation{"hole", fmt.Sprintf("hero hole must be %d cards for %s", g.HoleCards(), g)})
+	}
+	if n := len(community); n != 0 && n != 3 && n != 4 && n != 5 {
+		out = append(out, HandViolation{"community", "community must be 0, 3, 4, or 5 cards"})
+	}
+
+	seen := make(map[Card]bool)
+	reported := make(map[Card]bool)
+	check := func(field, name string, strs []string) {
+		for _, s := range strs {
+			c, err := ParseCard(s)
+			if err != nil {
+				out = append(out, HandViolation{field, fmt.Sprintf("invalid %s: %v", name, err)})
+				continue
+			}
+			if seen[c] && !reported[c] {
+				out = append(out, HandViolation{"cards", "duplicate card: " + s})
+				reported[c] = true
+			}
+			seen[c] = true
+		}
+	}
+	check("hole", "hero hole", hole)
+	check("community", "community", community)
+	return out
+}
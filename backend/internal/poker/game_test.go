@@ -0,0 +1,76 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGame(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Game
+		wantErr bool
+	}{
+		{"", Holdem, false},
+		{"holdem", Holdem, false},
+		{"omaha", Omaha, false},
+		{"stud", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseGame(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseGame(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+	if Holdem.HoleCards() != 2 || Omaha.HoleCards() != 4 {
+		t.Error("wrong hole card counts")
+	}
+	if Holdem.MaxOpponents() != 22 || Omaha.MaxOpponents() != 10 {
+		t.Errorf("MaxOpponents = %d, %d", Holdem.MaxOpponents(), Omaha.MaxOpponents())
+	}
+}
+
+func TestEvaluateOmahaBestHand(t *testing.T) {
+	tests := []struct {
+		name        string
+		hole, board []string
+		category    int
+		kickers     []Rank
+	}{
+		{"two hole hearts make a flush", []string{"Ah", "Kh", "Qc", "Jd"}, []string{"Th", "9h", "8h", "2c", "3d"}, Flush, []Rank{Ace, King, Ten, Nine, Eight}},
+		{"one heart cannot use a four-flush board", []string{"Ah", "Kc", "Qc", "Jd"}, []string{"Th", "9h", "8h", "7h", "2c"}, Straight, []Rank{Queen}},
+		{"board trips need two hole cards", []string{"Ah", "Kc", "Qc", "Jd"}, []string{"2h", "2d", "2c", "7h", "8s"}, ThreeOfAKind, []Rank{Two, Ace, King}},
+	}
+	for _, tt := range tests {
+		got := EvaluateOmahaBestHand(mustCards(t, tt.hole...), mustCards(t, tt.board...))
+		if want := (HandValue{tt.category, tt.kickers}); !sameHandValue(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestValidateHand(t *testing.T) {
+	tests := []struct {
+		name            string
+		game            string
+		hole, community []string
+		want            []HandViolation
+	}{
+		{"legal hold'em", "", []string{"Ah", "Kd"}, []string{"2c", "7d", "9h"}, nil},
+		{"legal omaha", "omaha", []string{"Ah", "Kd", "Qc", "Js"}, nil, nil},
+		{"unknown game", "stud", []string{"Ah"}, nil, []HandViolation{{"game", "unknown game: stud"}}},
+		{"wrong hole count", "omaha", []string{"Ah", "Kd"}, nil, []HandViolation{{"hole", "hero hole must be 4 cards for omaha"}}},
+		{"bad community count", "holdem", []string{"Ah", "Kd"}, []string{"2c"}, []HandViolation{{"community", "community must be 0, 3, 4, or 5 cards"}}},
+		{"duplicate reported once", "holdem", []string{"Ah", "HA"}, []string{"Ah", "2c", "3c"}, []HandViolation{{"cards", "duplicate card: HA"}}},
+	}
+	for _, tt := range tests {
+		if got := ValidateHand(tt.game, tt.hole, tt.community); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ValidateHand = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	got := ValidateHand("holdem", []string{"Ah", "?"}, []string{"Zz", "2c", "3c"})
+	if len(got) != 2 || got[0].Field != "hole" || got[1].Field != "community" {
+		t.Errorf("invalid cards: %v", got)
+	}
+}
@@ -0,0 +1,95 @@
+package poker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// GameState is a snapshot of a Hold'em hand for saving and resuming an
+// analysis session. Cards are stored as strings such as "HA".
+type GameState struct {
+	Players       [][]Card `json:"players"`                 // hole cards per seat; empty if folded
+	Board         []Card   `json:"board"`                   // 0, 3, 4 or 5 cards
+	Pot           int      `json:"pot"`                     // chips in the middle
+	Contributions []int    `json:"contributions,omitempty"` // chips each seat put in; sums to Pot
+}
+
+// maxSeats is the largest table a GameState may describe.
+const maxSeats = 9
+
+// Validate checks that s describes a playable hand: 2 to 9 seats holding
+// two cards each or none (folded), at least one of them live, a 0-, 3-, 4-
+// or 5-card board, no repeated or wild cards, and contributions, if given,
+// for every seat summing to the pot, with at least one live seat among the
+// contributors.
+func (s GameState) Validate() error {
+	if len(s.Players) < 2 || len(s.Players) > maxSeats {
+		return fmt.Errorf("need between 2 and %d players, got %d", maxSeats, len(s.Players))
+	}
+	var known []Card
+	live := 0
+	for i, h := range s.Players {
+		if len(h) != 0 && len(h) != 2 {
+			return fmt.Errorf("player %d must have 2 hole cards or none, got %d", i+1, len(h))
+		}
+		if len(h) == 2 {
+			live++
+		}
+		known = append(known, h...)
+	}
+	if live == 0 {
+		return fmt.Errorf("every player has folded")
+	}
+	if len(s.Board) != 0 && len(s.Board) != 3 && len(s.Board) != 4 && len(s.Board) != 5 {
+		return fmt.Errorf("board must be 0, 3, 4, or 5 cards, got %d", len(s.Board))
+	}
+	known = append(known, s.Board...)
+	if HasDuplicates(known) {
+		return fmt.Errorf("duplicate cards")
+	}
+
+	if s.Pot < 0 {
+		return fmt.Errorf("pot must be >= 0")
+	}
+	if len(s.Contributions) > 0 {
+		if len(s.Contributions) != len(s.Players) {
+			return fmt.Errorf("need one contribution per player, got %d for %d players", len(s.Contributions), len(s.Players))
+		}
+		sum := 0
+		liveContributor := false
+		for i, c := range s.Contributions {
+			if c < 0 {
+				return fmt.Errorf("contributions must be >= 0")
+			}
+			sum += c
+			if c > 0 && len(s.Players[i]) > 0 {
+				liveContributor = true
+			}
+		}
+		if sum != s.Pot {
+			return fmt.Errorf("contributions sum to %d but the pot is %d", sum, s.Pot)
+		}
+		if sum > 0 && !liveContributor {
+			return fmt.Errorf("no live player has contributed to the pot")
+		}
+	}
+	return nil
+}
+
+// LoadGameState decodes a GameState saved by SaveGameState and validates it.
+func LoadGameState(r io.Reader) (GameState, error) {
+	var s GameState
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return GameState{}, err
+	}
+	if err := s.Validate(); err != nil {
+		return GameState{}, err
+	}
+	return s, nil
+}
+
+// SaveGameState writes s as JSON.
+func SaveGameState(w io.Writer, s GameState) error {
+	return json.NewEncoder(w).Encode(s)
+}
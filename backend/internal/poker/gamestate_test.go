@@ -0,0 +1,51 @@
+package poker
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGameStateRoundTrip(t *testing.T) {
+	want := GameState{
+		Players:       [][]Card{mustCards(t, "Ah", "Ad"), {}, mustCards(t, "Kh", "Kd")},
+		Board:         mustCards(t, "2c", "7d", "9h"),
+		Pot:           150,
+		Contributions: []int{60, 30, 60},
+	}
+	var buf bytes.Buffer
+	if err := SaveGameState(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadGameState(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip: got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadGameStateRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name, json string
+	}{
+		{"not JSON", `{`},
+		{"one player", `{"players":[["HA","DA"]],"board":[],"pot":0}`},
+		{"one hole card", `{"players":[["HA"],["HK","DK"]],"board":[],"pot":0}`},
+		{"everyone folded", `{"players":[[],[]],"board":[],"pot":0}`},
+		{"two-card board", `{"players":[["HA","DA"],["HK","DK"]],"board":["C2","D7"],"pot":0}`},
+		{"duplicate card", `{"players":[["HA","DA"],["HA","DK"]],"board":[],"pot":0}`},
+		{"negative pot", `{"players":[["HA","DA"],["HK","DK"]],"board":[],"pot":-1}`},
+		{"contributions miss a seat", `{"players":[["HA","DA"],["HK","DK"]],"board":[],"pot":10,"contributions":[10]}`},
+		{"contributions do not sum", `{"players":[["HA","DA"],["HK","DK"]],"board":[],"pot":10,"contributions":[5,4]}`},
+		{"only folded contributors", `{"players":[["HA","DA"],[]],"board":[],"pot":10,"contributions":[0,10]}`},
+		{"invalid card", `{"players":[["HA","ZZ"],["HK","DK"]],"board":[],"pot":0}`},
+	}
+	for _, tt := range tests {
+		if _, err := LoadGameState(strings.NewReader(tt.json)); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package poker
 
 import (
+	"fmt"
+	"math/bits"
 	"sort"
 )
 
@@ -27,54 +29,298 @@ type HandValue struct {
 	Kickers  []Rank
 }
 
-// EvaluateBestHand takes exactly 7 cards (2 hole + 5 community)
-// and returns the best 5-card hand value.
+// Score packs a HandValue into a single integer that orders hands the same
+// way CompareHandValues does: the category in bits 20-23 followed by up to
+// five kickers, four bits each, most significant first. Higher is better.
+func (hv HandValue) Score() int {
+	score := hv.Category
+	for i := 0; i < 5; i++ {
+		score <<= 4
+		if i < len(hv.Kickers) {
+			score |= int(hv.Kickers[i])
+		}
+	}
+	return score
+}
+
+// key returns a string that is equal for two HandValues exactly when
+// CompareHandValues reports them equal, for use as a map key.
+func (hv HandValue) key() string {
+	b := make([]byte, 0, 1+len(hv.Kickers))
+	b = append(b, byte(hv.Category))
+	for _, r := range hv.Kickers {
+		b = append(b, byte(r))
+	}
+	return string(b)
+}
+
+// EvaluateBestHand takes 5, 6 or 7 cards (e.g. 2 hole + 5 community, or a
+// flop with hole cards) and returns the best 5-card hand value. It panics
+// on any other count; EvaluateHand reports an error instead.
 func EvaluateBestHand(cards []Card) HandValue {
-	if len(cards) != 7 {
-		panic("EvaluateBestHand requires exactly 7 cards")
+	if len(cards) < 5 || len(cards) > 7 {
+		panic("EvaluateBestHand requires 5 to 7 cards")
 	}
 
-	best := HandValue{Category: HighCard, Kickers: []Rank{Two}} // minimal
+	return evaluateCounts(cards)
+}
+
+// EvaluateHand is like EvaluateBestHand but returns an error, rather than
+// panicking, when given fewer than 5 or more than 7 cards.
+func EvaluateHand(cards []Card) (HandValue, error) {
+	if len(cards) < 5 || len(cards) > 7 {
+		return HandValue{}, fmt.Errorf("need 5 to 7 cards, got %d", len(cards))
+	}
+	return evaluateCounts(cards), nil
+}
 
-	// There are exactly C(7,5) = 21 5-card combinations.
-	indexes := []int{0, 1, 2, 3, 4}
+// evaluateCounts returns the best 5-card hand among 5 to 7 cards. Instead
+// of scoring every 5-card combination it builds a rank histogram and
+// per-suit rank bitmasks once and reads the best hand straight off them,
+// with straights looked up in straightTable. The result is identical to
+// the best evaluate5 value over all combinations, and the only allocation
+// is the Kickers slice.
+func evaluateCounts(cards []Card) HandValue {
+	var counts [Ace + 1]uint8
+	var suitMasks [4]uint16
+	var suitCounts [4]uint8
+	var rankMask uint16
+	for _, c := range cards {
+		counts[c.Rank]++
+		suitMasks[c.Suit] |= 1 << c.Rank
+		suitCounts[c.Suit]++
+		rankMask |= 1 << c.Rank
+	}
 
-	next := func() bool {
-		// Generate next combination in lexicographic order.
-		n := 7
-		k := 5
-		for i := k - 1; i >= 0; i-- {
-			if indexes[i] != i+n-k {
-				indexes[i]++
-				for j := i + 1; j < k; j++ {
-					indexes[j] = indexes[j-1] + 1
-				}
-				return true
-			}
+	// At most one suit can hold five of seven cards.
+	flushMask := uint16(0)
+	for s, n := range suitCounts {
+		if n >= 5 {
+			flushMask = suitMasks[s]
+		}
+	}
+	if flushMask != 0 {
+		if top := straightTable[flushMask>>Two]; top != 0 {
+			return HandValue{Category: StraightFlush, Kickers: []Rank{top}}
 		}
-		return false
 	}
 
-	evalCombo := func() HandValue {
+	var quadMask, tripMask, pairMask uint16
+	for r := Two; r <= Ace; r++ {
+		switch counts[r] {
+		case 4:
+			quadMask |= 1 << r
+		case 3:
+			tripMask |= 1 << r
+		case 2:
+			pairMask |= 1 << r
+		}
+	}
+
+	kickers := make([]Rank, 0, 5)
+	if quadMask != 0 {
+		q := highestRank(quadMask)
+		return HandValue{Category: FourOfAKind, Kickers: appendTopRanks(append(kickers, q), rankMask&^(1<<q), 1)}
+	}
+	if tripMask != 0 {
+		t := highestRank(tripMask)
+		// The pair part may be a second set of trips.
+		if rest := (tripMask | pairMask) &^ (1 << t); rest != 0 {
+			return HandValue{Category: FullHouse, Kickers: append(kickers, t, highestRank(rest))}
+		}
+	}
+	if flushMask != 0 {
+		return HandValue{Category: Flush, Kickers: appendTopRanks(kickers, flushMask, 5)}
+	}
+	if top := straightTable[rankMask>>Two]; top != 0 {
+		return HandValue{Category: Straight, Kickers: append(kickers, top)}
+	}
+	if tripMask != 0 {
+		t := highestRank(tripMask)
+		return HandValue{Category: ThreeOfAKind, Kickers: appendTopRanks(append(kickers, t), rankMask&^(1<<t), 2)}
+	}
+	if pairMask != 0 {
+		p1 := highestRank(pairMask)
+		if rest := pairMask &^ (1 << p1); rest != 0 {
+			p2 := highestRank(rest)
+			return HandValue{Category: TwoPair, Kickers: appendTopRanks(append(kickers, p1, p2), rankMask&^(1<<p1|1<<p2), 1)}
+		}
+		return HandValue{Category: OnePair, Kickers: appendTopRanks(append(kickers, p1), rankMask&^(1<<p1), 3)}
+	}
+	return HandValue{Category: HighCard, Kickers: appendTopRanks(kickers, rankMask, 5)}
+}
+
+// straightTable maps a 13-bit rank mask (bit r-Two set for rank r) to the
+// top rank of its highest straight, or 0 if it holds none.
+var straightTable = buildStraightTable()
+
+func buildStraightTable() [1 << 13]Rank {
+	var t [1 << 13]Rank
+	for m := range t {
+		if top, ok := straightTop(uint16(m) << Two); ok {
+			t[m] = top
+		}
+	}
+	return t
+}
+
+// straightTop returns the top rank of the highest straight in a rank
+// bitmask (bit r set for rank r), counting A-2-3-4-5 as Five high.
+func straightTop(mask uint16) (Rank, bool) {
+	for top := Ace; top >= Six; top-- {
+		window := uint16(0x1f) << (top - 4)
+		if mask&window == window {
+			return top, true
+		}
+	}
+	wheel := uint16(1)<<Ace | uint16(0xf)<<Two
+	if mask&wheel == wheel {
+		return Five, true
+	}
+	return 0, false
+}
+
+// highestRank returns the highest rank set in a non-empty rank mask.
+func highestRank(mask uint16) Rank {
+	return Rank(bits.Len16(mask) - 1)
+}
+
+// appendTopRanks appends the n highest ranks set in mask to dst.
+func appendTopRanks(dst []Rank, mask uint16, n int) []Rank {
+	for ; n > 0 && mask != 0; n-- {
+		r := highestRank(mask)
+		dst = append(dst, r)
+		mask &^= 1 << r
+	}
+	return dst
+}
+
+// AllFiveCardValues returns the HandValue of every 5-card combination of
+// cards, in the lexicographic order produced by Combinations. For a 7-card
+// hand that is 21 values.
+func AllFiveCardValues(cards []Card) []HandValue {
+	combos := Combinations(len(cards), 5)
+	out := make([]HandValue, len(combos))
+	for i, idx := range combos {
 		hand := []Card{
-			cards[indexes[0]],
-			cards[indexes[1]],
-			cards[indexes[2]],
-			cards[indexes[3]],
-			cards[indexes[4]],
+			cards[idx[0]],
+			cards[idx[1]],
+			cards[idx[2]],
+			cards[idx[3]],
+			cards[idx[4]],
 		}
-		return evaluate5(hand)
+		out[i] = evaluate5(hand)
+	}
+	return out
+}
+
+// SplitBestHand evaluates 5 to 7 cards like EvaluateBestHand and also
+// returns the five cards forming the best hand and the cards left out,
+// each in input order. When several combinations tie, the first in
+// Combinations order is used, so the choice is deterministic.
+func SplitBestHand(cards []Card) (hv HandValue, used, unused []Card) {
+	if len(cards) < 5 || len(cards) > 7 {
+		panic("SplitBestHand requires 5 to 7 cards")
+	}
+
+	combos := Combinations(len(cards), 5)
+	bestIdx := 0
+	for i, v := range AllFiveCardValues(cards) {
+		if i == 0 || CompareHandValues(v, hv) > 0 {
+			hv = v
+			bestIdx = i
+		}
+	}
+
+	inBest := make([]bool, len(cards))
+	for _, i := range combos[bestIdx] {
+		inBest[i] = true
+	}
+	for i, c := range cards {
+		if inBest[i] {
+			used = append(used, c)
+		} else {
+			unused = append(unused, c)
+		}
+	}
+	return hv, used, unused
+}
+
+// EvaluateBestHandWithCards is EvaluateBestHand that also returns the five
+// cards making the best hand, as given (with their suits), so a UI can
+// highlight them. Ties are broken as in SplitBestHand.
+func EvaluateBestHandWithCards(cards []Card) (HandValue, []Card) {
+	hv, used, _ := SplitBestHand(cards)
+	return hv, used
+}
+
+// Combinations returns every k-element subset of {0, ..., n-1} as a sorted
+// index slice, in lexicographic order.
+func Combinations(n, k int) [][]int {
+	if k < 0 || k > n {
+		return nil
 	}
 
-	best = evalCombo()
-	for next() {
-		hv := evalCombo()
-		if CompareHandValues(hv, best) > 0 {
-			best = hv
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	var out [][]int
+	for {
+		out = append(out, append([]int(nil), indexes...))
+
+		// Generate next combination in lexicographic order.
+		i := k - 1
+		for i >= 0 && indexes[i] == i+n-k {
+			i--
+		}
+		if i < 0 {
+			return out
+		}
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
 		}
 	}
+}
 
-	return best
+// PlaysTheBoard reports whether a player's best hand comes entirely from the
+// five community cards, i.e. their hole cards do not improve on the board.
+func PlaysTheBoard(hole, community []Card) bool {
+	if len(hole) != 2 || len(community) != 5 {
+		panic("PlaysTheBoard requires 2 hole cards and 5 community cards")
+	}
+	seven := append([]Card{}, hole...)
+	seven = append(seven, community...)
+	board := append([]Card{}, community...)
+	return CompareHandValues(EvaluateBestHand(seven), evaluate5(board)) == 0
+}
+
+// Split reasons reported by SplitReason.
+const (
+	SplitBothPlayBoard  = "both_play_board"
+	SplitIdenticalHands = "identical_hands"
+)
+
+// SplitReason explains why two tied hands split the pot: SplitBothPlayBoard
+// when neither player's hole cards improve on the board, otherwise
+// SplitIdenticalHands (equal hands made with at least one player's hole
+// cards). It assumes the hands have already been found to tie.
+func SplitReason(hole1, hole2, community []Card) string {
+	if PlaysTheBoard(hole1, community) && PlaysTheBoard(hole2, community) {
+		return SplitBothPlayBoard
+	}
+	return SplitIdenticalHands
+}
+
+// EvaluateFiveCardDraw evaluates a 5-card hand with no community cards, as
+// in Five-Card Draw. The input slice is not modified.
+func EvaluateFiveCardDraw(hand []Card) HandValue {
+	if len(hand) != 5 {
+		panic("EvaluateFiveCardDraw requires exactly 5 cards")
+	}
+	return evaluate5(append([]Card(nil), hand...))
 }
 
 // evaluate5 evaluates exactly 5 cards and returns their HandValue.
@@ -190,6 +436,12 @@ func evaluate5(cards []Card) HandValue {
 }
 
 // CompareHandValues returns 1 if a > b, -1 if a < b, 0 if equal.
+//
+// It is a total preorder on HandValues: for any a, b, c it is
+// antisymmetric (Compare(a, b) == -Compare(b, a)), transitive, and returns 0
+// exactly when a and b have the same category and kicker list. Values from
+// the evaluators always carry the same number of kickers for a given
+// category; if lengths do differ, a list that extends the other wins.
 func CompareHandValues(a, b HandValue) int {
 	if a.Category != b.Category {
 		if a.Category > b.Category {
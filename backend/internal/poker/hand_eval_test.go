@@ -0,0 +1,356 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// mustCards parses cards in any notation ParseCard accepts.
+func mustCards(t testing.TB, strs ...string) []Card {
+	t.Helper()
+	out := make([]Card, len(strs))
+	for i, s := range strs {
+		c, err := ParseCard(s)
+		if err != nil {
+			t.Fatalf("ParseCard(%q): %v", s, err)
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// bestOfCombinations is the combinatorial reference evaluator: the best
+// evaluate5 value over every 5-card subset of cards.
+func bestOfCombinations(cards []Card) HandValue {
+	var best HandValue
+	for i, hv := range AllFiveCardValues(cards) {
+		if i == 0 || CompareHandValues(hv, best) > 0 {
+			best = hv
+		}
+	}
+	return best
+}
+
+// forEachFiveCardHand calls fn with every 5-card hand from a full deck.
+// The slice is reused between calls.
+func forEachFiveCardHand(fn func(hand []Card)) {
+	deck := FullDeck()
+	hand := make([]Card, 5)
+	for a := 0; a < 52; a++ {
+		for b := a + 1; b < 52; b++ {
+			for c := b + 1; c < 52; c++ {
+				for d := c + 1; d < 52; d++ {
+					for e := d + 1; e < 52; e++ {
+						hand[0], hand[1], hand[2], hand[3], hand[4] = deck[a], deck[b], deck[c], deck[d], deck[e]
+						fn(hand)
+					}
+				}
+			}
+		}
+	}
+}
+
+func sameHandValue(a, b HandValue) bool {
+	return CompareHandValues(a, b) == 0 && len(a.Kickers) == len(b.Kickers)
+}
+
+func TestEvaluateBestHandMatchesEvaluate5OnEveryFiveCardHand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates all 2,598,960 hands")
+	}
+	n := 0
+	forEachFiveCardHand(func(hand []Card) {
+		n++
+		got := EvaluateBestHand(hand)
+		want := evaluate5(append([]Card(nil), hand...))
+		if !sameHandValue(got, want) {
+			t.Fatalf("EvaluateBestHand(%v) = %v, evaluate5 = %v", hand, got, want)
+		}
+	})
+	if n != 2598960 {
+		t.Fatalf("enumerated %d hands, want 2598960", n)
+	}
+}
+
+func TestEvaluateBestHandMatchesCombinations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i, hand := range RandomHands(20000, rng) {
+		cards := hand[:5+i%3]
+		got, want := EvaluateBestHand(cards), bestOfCombinations(cards)
+		if !sameHandValue(got, want) {
+			t.Fatalf("EvaluateBestHand(%v) = %v, combinations give %v", cards, got, want)
+		}
+	}
+}
+
+// FuzzEvaluateBestHand maps each input byte to a card, skipping repeats,
+// and checks the first 5 to 7 distinct cards against the combinatorial
+// reference.
+func FuzzEvaluateBestHand(f *testing.F) {
+	f.Add([]byte{0, 1, 2, 3, 4, 5, 6})
+	f.Add([]byte{12, 25, 38, 51, 11, 24, 37})
+	f.Add([]byte{0, 13, 26, 39, 1, 14})
+	deck := FullDeck()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var seen [52]bool
+		var cards []Card
+		for _, b := range data {
+			c := deck[int(b)%52]
+			if seen[c.index()] || len(cards) == 7 {
+				continue
+			}
+			seen[c.index()] = true
+			cards = append(cards, c)
+		}
+		if len(cards) < 5 {
+			t.Skip()
+		}
+		if got, want := EvaluateBestHand(cards), bestOfCombinations(cards); !sameHandValue(got, want) {
+			t.Fatalf("EvaluateBestHand(%v) = %v, combinations give %v", cards, got, want)
+		}
+	})
+}
+
+func TestEvaluateBestHand(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    []string
+		category int
+		kickers  []Rank
+	}{
+		{"royal flush", []string{"Ah", "Kh", "Qh", "Jh", "Th", "2c", "3d"}, StraightFlush, []Rank{Ace}},
+		{"steel wheel", []string{"Ah", "2h", "3h", "4h", "5h", "Kc", "Kd"}, StraightFlush, []Rank{Five}},
+		{"quads with best kicker", []string{"9c", "9d", "9h", "9s", "2c", "Kd", "Qh"}, FourOfAKind, []Rank{Nine, King}},
+		{"two trips make a full house", []string{"8c", "8d", "8h", "5s", "5c", "5d", "Ah"}, FullHouse, []Rank{Eight, Five}},
+		{"trips over the higher pair", []string{"7c", "7d", "7h", "Ks", "Kc", "2d", "2h"}, FullHouse, []Rank{Seven, King}},
+		{"flush beats straight", []string{"2h", "6h", "9h", "Jh", "Kh", "Tc", "Qd"}, Flush, []Rank{King, Jack, Nine, Six, Two}},
+		{"six-card flush plays top five", []string{"2h", "6h", "9h", "Jh", "Kh", "3h", "Ad"}, Flush, []Rank{King, Jack, Nine, Six, Three}},
+		{"broadway", []string{"As", "Kd", "Qc", "Jh", "Tc", "2d", "3h"}, Straight, []Rank{Ace}},
+		{"wheel", []string{"As", "2d", "3c", "4h", "5c", "9d", "Kh"}, Straight, []Rank{Five}},
+		{"trips", []string{"Qs", "Qd", "Qc", "4h", "7c", "9d", "2h"}, ThreeOfAKind, []Rank{Queen, Nine, Seven}},
+		{"three pairs play top two", []string{"Js", "Jd", "4c", "4h", "3c", "3d", "2h"}, TwoPair, []Rank{Jack, Four, Three}},
+		{"one pair", []string{"Ts", "Td", "Ac", "8h", "6c", "4d", "2h"}, OnePair, []Rank{Ten, Ace, Eight, Six}},
+		{"high card", []string{"As", "Jd", "9c", "7h", "5c", "3d", "2h"}, HighCard, []Rank{Ace, Jack, Nine, Seven, Five}},
+		{"five cards", []string{"As", "Ad", "Kc", "Kh", "5c"}, TwoPair, []Rank{Ace, King, Five}},
+		{"six cards", []string{"As", "Ad", "Kc", "Kh", "5c", "5d"}, TwoPair, []Rank{Ace, King, Five}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateBestHand(mustCards(t, tt.cards...))
+			want := HandValue{Category: tt.category, Kickers: tt.kickers}
+			if !sameHandValue(got, want) {
+				t.Errorf("EvaluateBestHand(%v) = %v, want %v", tt.cards, got, want)
+			}
+		})
+	}
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+func TestCompareHandValuesOrdering(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	hands := RandomHands(600, rng)
+	values := make([]HandValue, len(hands))
+	for i, h := range hands {
+		values[i] = EvaluateBestHand(h)
+	}
+	for _, a := range values {
+		if CompareHandValues(a, a) != 0 {
+			t.Fatalf("Compare(%v, itself) != 0", a)
+		}
+		for j, b := range values {
+			ab, ba := CompareHandValues(a, b), CompareHandValues(b, a)
+			if ab != -ba {
+				t.Fatalf("Compare(%v, %v) = %d but Compare(b, a) = %d", a, b, ab, ba)
+			}
+			if s := sign(a.Score() - b.Score()); s != ab {
+				t.Fatalf("Compare(%v, %v) = %d, Score order %d", a, b, ab, s)
+			}
+			if k := j + 1; k < len(values) {
+				c := values[k]
+				if ab >= 0 && CompareHandValues(b, c) >= 0 && CompareHandValues(a, c) < 0 {
+					t.Fatalf("not transitive: %v >= %v >= %v but %v < %v", a, b, c, a, c)
+				}
+			}
+		}
+	}
+}
+
+func TestCompareHandValuesKickerLengths(t *testing.T) {
+	tests := []struct {
+		a, b HandValue
+		want int
+	}{
+		{HandValue{OnePair, []Rank{Ace, King}}, HandValue{OnePair, []Rank{Ace}}, 1},
+		{HandValue{OnePair, []Rank{Ace}}, HandValue{OnePair, []Rank{Ace, Two}}, -1},
+		{HandValue{Flush, nil}, HandValue{Straight, []Rank{Ace}}, 1},
+		{HandValue{HighCard, []Rank{Ace, King}}, HandValue{HighCard, []Rank{Ace, King}}, 0},
+	}
+	for _, tt := range tests {
+		if got := CompareHandValues(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareHandValues(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := CompareHandValues(tt.b, tt.a); got != -tt.want {
+			t.Errorf("CompareHandValues(%v, %v) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+var benchHands = RandomHands(1024, rand.New(rand.NewSource(2)))
+
+func BenchmarkEvaluateBestHand(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		EvaluateBestHand(benchHands[i%len(benchHands)])
+	}
+}
+
+func BenchmarkEvaluateBestHandCombinatorial(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bestOfCombinations(benchHands[i%len(benchHands)])
+	}
+}
+
+func TestEvaluateHand(t *testing.T) {
+	for _, n := range []int{0, 4, 8} {
+		if hv, err := EvaluateHand(FullDeck()[:n]); err == nil {
+			t.Errorf("EvaluateHand with %d cards = %v, want error", n, hv)
+		}
+	}
+	cards := mustCards(t, "As", "Ad", "Kc", "Kh", "5c", "5d", "2h")
+	hv, err := EvaluateHand(cards)
+	if err != nil || !sameHandValue(hv, EvaluateBestHand(cards)) {
+		t.Errorf("EvaluateHand = %v, %v", hv, err)
+	}
+}
+
+func TestSplitBestHand(t *testing.T) {
+	tests := []struct {
+		name         string
+		cards        []string
+		used, unused string
+	}{
+		{"flush", []string{"2h", "Kc", "6h", "9h", "Jh", "Kh", "Qd"}, "H2 H6 H9 HJ HK", "CK DQ"},
+		{"two pair keeps the best kicker", []string{"As", "Ad", "Kc", "Kh", "5c", "5d", "2h"}, "SA DA CK HK C5", "D5 H2"},
+		{"five cards use them all", []string{"As", "Ad", "Kc", "Kh", "5c"}, "SA DA CK HK C5", ""},
+	}
+	for _, tt := range tests {
+		cards := mustCards(t, tt.cards...)
+		hv, used, unused := SplitBestHand(cards)
+		if !sameHandValue(hv, EvaluateBestHand(cards)) {
+			t.Errorf("%s: value %v", tt.name, hv)
+		}
+		if got := cardStrs(used); got != tt.used {
+			t.Errorf("%s: used = %s, want %s", tt.name, got, tt.used)
+		}
+		if got := cardStrs(unused); got != tt.unused {
+			t.Errorf("%s: unused = %s, want %s", tt.name, got, tt.unused)
+		}
+	}
+}
+
+func TestEvaluateBestHandWithCards(t *testing.T) {
+	tests := []struct {
+		cards []string
+		best  string
+	}{
+		{[]string{"2h", "Kc", "6h", "9h", "Jh", "Kh", "Qd"}, "H2 H6 H9 HJ HK"},
+		{[]string{"As", "Ad", "Kc", "Kh", "5c", "5d", "2h"}, "SA DA CK HK C5"},
+		{[]string{"7c", "6d", "5h", "4s", "3c", "2d"}, "C7 D6 H5 S4 C3"},
+	}
+	for _, tt := range tests {
+		cards := mustCards(t, tt.cards...)
+		hv, best := EvaluateBestHandWithCards(cards)
+		if got := cardStrs(best); got != tt.best {
+			t.Errorf("%v: best = %s, want %s", tt.cards, got, tt.best)
+		}
+		if !sameHandValue(hv, EvaluateBestHand(cards)) {
+			t.Errorf("%v: value %v", tt.cards, hv)
+		}
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		n, k, want int
+	}{
+		{7, 5, 21}, {6, 5, 6}, {5, 5, 1}, {5, 0, 1}, {4, 5, 0}, {5, -1, 0}, {52, 2, 1326},
+	}
+	for _, tt := range tests {
+		if got := len(Combinations(tt.n, tt.k)); got != tt.want {
+			t.Errorf("len(Combinations(%d, %d)) = %d, want %d", tt.n, tt.k, got, tt.want)
+		}
+	}
+	combos := Combinations(4, 2)
+	want := [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}
+	for i := range want {
+		if len(combos[i]) != 2 || combos[i][0] != want[i][0] || combos[i][1] != want[i][1] {
+			t.Fatalf("Combinations(4, 2) = %v, want %v", combos, want)
+		}
+	}
+	if got := len(AllFiveCardValues(FullDeck()[:7])); got != 21 {
+		t.Errorf("len(AllFiveCardValues) = %d, want 21", got)
+	}
+}
+
+func TestPlaysTheBoard(t *testing.T) {
+	tests := []struct {
+		hole, board []string
+		want        bool
+	}{
+		{[]string{"2c", "3d"}, []string{"Ah", "Kd", "Qc", "Js", "Th"}, true},
+		{[]string{"Qc", "3d"}, []string{"Ah", "Kd", "7c", "4s", "2h"}, false},
+		{[]string{"2c", "3d"}, []string{"Ah", "Ad", "Kc", "Ks", "Qh"}, true},
+	}
+	for _, tt := range tests {
+		if got := PlaysTheBoard(mustCards(t, tt.hole...), mustCards(t, tt.board...)); got != tt.want {
+			t.Errorf("PlaysTheBoard(%v, %v) = %v, want %v", tt.hole, tt.board, got, tt.want)
+		}
+	}
+}
+
+func TestSplitReason(t *testing.T) {
+	tests := []struct {
+		hole1, hole2, board []string
+		want                string
+	}{
+		{[]string{"2c", "3d"}, []string{"4c", "5d"}, []string{"Ah", "Kd", "Qc", "Js", "Th"}, SplitBothPlayBoard},
+		{[]string{"Qc", "3d"}, []string{"Qs", "3c"}, []string{"Ah", "Kd", "7c", "4s", "2h"}, SplitIdenticalHands},
+	}
+	for _, tt := range tests {
+		got := SplitReason(mustCards(t, tt.hole1...), mustCards(t, tt.hole2...), mustCards(t, tt.board...))
+		if got != tt.want {
+			t.Errorf("SplitReason(%v, %v, %v) = %q, want %q", tt.hole1, tt.hole2, tt.board, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateFiveCardDraw(t *testing.T) {
+	hand := mustCards(t, "2c", "Ad", "2h", "Ks", "Ac")
+	got := EvaluateFiveCardDraw(hand)
+	if want := (HandValue{TwoPair, []Rank{Ace, Two, King}}); !sameHandValue(got, want) {
+		t.Errorf("EvaluateFiveCardDraw = %v, want %v", got, want)
+	}
+	if hand[0].Str != "C2" {
+		t.Errorf("EvaluateFiveCardDraw reordered its input: %v", hand)
+	}
+}
+
+func TestRandomHands(t *testing.T) {
+	hands := RandomHands(500, rand.New(rand.NewSource(4)))
+	if len(hands) != 500 {
+		t.Fatalf("got %d hands", len(hands))
+	}
+	for _, h := range hands {
+		if len(h) != 7 || HasDuplicates(h) {
+			t.Fatalf("bad hand %v", h)
+		}
+	}
+}
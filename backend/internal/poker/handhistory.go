@@ -0,0 +1,102 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsePokerStarsHand extracts hero's hole cards and the board from a
+// PokerStars-style Hold'em hand history. Only these lines are read:
+//
+//	Dealt to Hero [As Ks]
+//	*** FLOP *** [2h 7c 9d]
+//	*** TURN *** [2h 7c 9d] [Jc]
+//	*** RIVER *** [2h 7c 9d Jc] [3s]
+//	Board [2h 7c 9d Jc 3s]
+//
+// The first "Dealt to" line with cards is taken as hero's. The board is
+// built from the street lines, or taken from the summary "Board" line when
+// no street lines are present; a hand that ended preflop has an empty
+// board. Everything else in the text is ignored.
+func ParsePokerStarsHand(text string) (hole, board []Card, err error) {
+	var summary []Card
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		var street string
+		for _, prefix := range []string{"Dealt to ", "*** FLOP ***", "*** TURN ***", "*** RIVER ***", "Board "} {
+			if strings.HasPrefix(line, prefix) {
+				street = prefix
+			}
+		}
+		if street == "" {
+			continue
+		}
+		groups, err := bracketGroups(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %v", n+1, err)
+		}
+
+		switch street {
+		case "Dealt to ":
+			if hole == nil && len(groups) > 0 {
+				hole = groups[0]
+			}
+		case "*** FLOP ***":
+			if len(groups) != 1 || len(groups[0]) != 3 || len(board) != 0 {
+				return nil, nil, fmt.Errorf("line %d: malformed flop", n+1)
+			}
+			board = append(board, groups[0]...)
+		case "*** TURN ***", "*** RIVER ***":
+			if len(groups) != 2 || len(groups[1]) != 1 || len(board) < 3 {
+				return nil, nil, fmt.Errorf("line %d: malformed turn or river", n+1)
+			}
+			board = append(board, groups[1][0])
+		case "Board ":
+			if len(groups) == 1 {
+				summary = groups[0]
+			}
+		}
+	}
+
+	if hole == nil {
+		return nil, nil, fmt.Errorf("no \"Dealt to\" line with hole cards")
+	}
+	if len(hole) != 2 {
+		return nil, nil, fmt.Errorf("hero must have 2 hole cards, got %d", len(hole))
+	}
+	if board == nil {
+		board = summary
+	}
+	if len(board) != 0 && len(board) != 3 && len(board) != 4 && len(board) != 5 {
+		return nil, nil, fmt.Errorf("board must be 0, 3, 4, or 5 cards, got %d", len(board))
+	}
+	if HasDuplicates(append(append([]Card{}, hole...), board...)) {
+		return nil, nil, fmt.Errorf("duplicate cards")
+	}
+	return hole, board, nil
+}
+
+// bracketGroups parses every "[...]" group of space-separated cards in line.
+func bracketGroups(line string) ([][]Card, error) {
+	var groups [][]Card
+	for {
+		open := strings.IndexByte(line, '[')
+		if open < 0 {
+			return groups, nil
+		}
+		end := strings.IndexByte(line[open:], ']')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed '['")
+		}
+		var cards []Card
+		for _, f := range strings.Fields(line[open+1 : open+end]) {
+			c, err := ParseCard(f)
+			if err != nil {
+				return nil, err
+			}
+			cards = append(cards, c)
+		}
+		groups = append(groups, cards)
+		line = line[open+end+1:]
+	}
+}
@@ -0,0 +1,63 @@
+package poker
+
+import "testing"
+
+const starsHand = `PokerStars Hand #1: Hold'em No Limit ($0.01/$0.02)
+Seat 1: Hero ($2 in chips)
+Seat 2: Villain ($2 in chips)
+*** HOLE CARDS ***
+Dealt to Hero [As Ks]
+Villain: raises $0.04 to $0.06
+*** FLOP *** [2h 7c 9d]
+*** TURN *** [2h 7c 9d] [Jc]
+*** RIVER *** [2h 7c 9d Jc] [3s]
+*** SUMMARY ***
+Board [2h 7c 9d Jc 3s]
+`
+
+func TestParsePokerStarsHand(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		hole, board string
+	}{
+		{"full hand", starsHand, "SA SK", "H2 C7 D9 CJ S3"},
+		{"ended on the flop", "Dealt to Hero [Th Td]\n*** FLOP *** [2h 7c 9d]\n", "HT DT", "H2 C7 D9"},
+		{"ended preflop", "Dealt to Hero [Th Td]\nVillain: folds\n", "HT DT", ""},
+		{"summary board only", "Dealt to Hero [Th Td]\nBoard [2h 7c 9d Jc]\n", "HT DT", "H2 C7 D9 CJ"},
+		{"first dealt line wins", "Dealt to Hero [Th Td]\nDealt to Villain [2c 3c]\n", "HT DT", ""},
+	}
+	for _, tt := range tests {
+		hole, board, err := ParsePokerStarsHand(tt.text)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := cardStrs(hole); got != tt.hole {
+			t.Errorf("%s: hole = %s, want %s", tt.name, got, tt.hole)
+		}
+		if got := cardStrs(board); got != tt.board {
+			t.Errorf("%s: board = %s, want %s", tt.name, got, tt.board)
+		}
+	}
+}
+
+func TestParsePokerStarsHandErrors(t *testing.T) {
+	tests := []struct {
+		name, text string
+	}{
+		{"no hole cards", "*** FLOP *** [2h 7c 9d]\n"},
+		{"one hole card", "Dealt to Hero [Th]\n"},
+		{"short flop", "Dealt to Hero [Th Td]\n*** FLOP *** [2h 7c]\n"},
+		{"turn before flop", "Dealt to Hero [Th Td]\n*** TURN *** [2h 7c 9d] [Jc]\n"},
+		{"bad card", "Dealt to Hero [Th Zz]\n"},
+		{"unclosed bracket", "Dealt to Hero [Th Td\n"},
+		{"duplicate card", "Dealt to Hero [Th Td]\n*** FLOP *** [Th 7c 9d]\n"},
+		{"two-card summary board", "Dealt to Hero [Th Td]\nBoard [2h 7c]\n"},
+	}
+	for _, tt := range tests {
+		if _, _, err := ParsePokerStarsHand(tt.text); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package poker
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// Importance sampling
+//
+// With a strong hand, hero rarely loses, so uniform sampling sees few losing
+// deals and the loss estimate is noisy. importanceWorker deals so that
+// losing deals come up far more often and reweights each trial to keep the
+// estimate unbiased:
+//
+//  1. Board cards are drawn with probability proportional to a weight per
+//     card: the chance that one of numOpponents random holdings beats hero
+//     once that card is added, plus the average of that chance over all
+//     cards so that harmless cards stay well covered.
+//  2. Once the board is complete, each opponent is dealt, with probability
+//     importanceBeatShare, a holding picked uniformly from those that beat
+//     hero on that board, and otherwise a uniformly random holding. The
+//     beating holdings are enumerated once per board and shared by all
+//     workers.
+//
+// Every trial carries the likelihood ratio of the uniform deal to the deal
+// actually made. For a board card that is W / (n*w), where n is the number
+// of cards left, w the drawn card's weight and W the total remaining
+// weight. For a holding it is 1 / (N * (s/B + (1-s)/N)) if it beats hero
+// and 1/(1-s) otherwise, where N is the number of holdings left, B the
+// number of those that beat hero and s is importanceBeatShare. Summing each
+// outcome's ratios and dividing by the trial count gives an unbiased
+// estimate of its probability (see SimulationResult.Rates).
+//
+// Preflop a single card says little about who wins, so the board weights
+// are uniform there; the holding step still applies.
+
+// importanceBeatShare is the share of opponents dealt a holding that beats
+// hero.
+const importanceBeatShare = 0.5
+
+// importanceWeights returns the sampling weight of each card in deck, in
+// deck order, or nil for uniform weights.
+func importanceWeights(heroHole, community, deck []Card, numOpponents int) []float64 {
+	if len(community) < 3 || len(community) > 4 {
+		return nil
+	}
+
+	rates := make([]float64, len(deck))
+	mean := 0.0
+	board := append(append([]Card{}, community...), Card{})
+	for i, c := range deck {
+		board[len(board)-1] = c
+		hero := EvaluateBestHand(append(append([]Card{}, heroHole...), board...))
+		beaten, holdings := 0, 0
+		for a := 0; a < len(deck); a++ {
+			for b := a + 1; b < len(deck); b++ {
+				if a == i || b == i {
+					continue
+				}
+				holdings++
+				if CompareHandValues(EvaluateBestHand(append([]Card{deck[a], deck[b]}, board...)), hero) > 0 {
+					beaten++
+				}
+			}
+		}
+		rates[i] = 1 - math.Pow(1-float64(beaten)/float64(holdings), float64(numOpponents))
+		mean += rates[i] / float64(len(deck))
+	}
+	if mean == 0 {
+		// Hero cannot lose whatever comes.
+		return nil
+	}
+
+	for i, r := range rates {
+		rates[i] = r + mean
+	}
+	return rates
+}
+
+// beatingHoldings caches, per complete board, the two-card holdings that
+// beat hero on it.
+type beatingHoldings struct {
+	heroHole []Card
+	deck     []Card
+
+	mu      sync.Mutex
+	byBoard map[uint64][][2]Card
+}
+
+// get returns the holdings from deck, avoiding the board, that beat hero
+// on board.
+func (h *beatingHoldings) get(board []Card) [][2]Card {
+	key := cardMask(board)
+	h.mu.Lock()
+	beats, ok := h.byBoard[key]
+	h.mu.Unlock()
+	if ok {
+		return beats
+	}
+
+	hero := EvaluateBestHand(append(append([]Card{}, h.heroHole...), board...))
+	hand := append([]Card{{}, {}}, board...)
+	for a := 0; a < len(h.deck); a++ {
+		if key&cardMask(h.deck[a:a+1]) != 0 {
+			continue
+		}
+		for b := a + 1; b < len(h.deck); b++ {
+			if key&cardMask(h.deck[b:b+1]) != 0 {
+				continue
+			}
+			hand[0], hand[1] = h.deck[a], h.deck[b]
+			if CompareHandValues(EvaluateBestHand(hand), hero) > 0 {
+				beats = append(beats, [2]Card{h.deck[a], h.deck[b]})
+			}
+		}
+	}
+
+	h.mu.Lock()
+	h.byBoard[key] = beats
+	h.mu.Unlock()
+	return beats
+}
+
+// cardMask returns cards as a bit set over Card.index.
+func cardMask(cards []Card) uint64 {
+	var m uint64
+	for _, c := range cards {
+		m |= 1 << uint(c.index())
+	}
+	return m
+}
+
+func importanceWorker(heroHole, community, deck []Card, numOpponents int) func(*rand.Rand, *SimulationResult, int) {
+	toDraw := 5 - len(community)
+	weights := importanceWeights(heroHole, community, deck, numOpponents)
+	if weights == nil {
+		weights = make([]float64, len(deck))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	beating := &beatingHoldings{heroHole: heroHole, deck: deck, byBoard: map[uint64][][2]Card{}}
+
+	return func(rng *rand.Rand, local *SimulationResult, n int) {
+		local.ImportanceSampled = true
+		tmp := make([]Card, len(deck))
+		w := make([]float64, len(deck))
+		board := append([]Card{}, community...)
+		dealt := make([]Card, 0, toDraw+2*numOpponents)
+		var open [][2]Card
+		for t := 0; t < n; t++ {
+			copy(tmp, deck)
+			copy(w, weights)
+			total := 0.0
+			for _, x := range w {
+				total += x
+			}
+
+			// Draw the missing board cards into the front of tmp.
+			ratio := 1.0
+			for i := 0; i < toDraw; i++ {
+				left := len(tmp) - i
+				pick := rng.Float64() * total
+				j := i
+				for ; j < len(tmp)-1; j++ {
+					pick -= w[j]
+					if pick < 0 {
+						break
+					}
+				}
+				ratio *= total / (float64(left) * w[j])
+				total -= w[j]
+				tmp[i], tmp[j] = tmp[j], tmp[i]
+				w[i], w[j] = w[j], w[i]
+			}
+			board = append(board[:len(community)], tmp[:toDraw]...)
+			dealt = append(dealt[:0], tmp[:toDraw]...)
+
+			// Deal the opponents from the rest, mixing in holdings that
+			// beat hero.
+			beats := beating.get(board)
+			rest := tmp[toDraw:]
+			used := cardMask(board)
+			for k := 0; k < numOpponents; k++ {
+				open = open[:0]
+				for _, hold := range beats {
+					if used&cardMask(hold[:]) == 0 {
+						open = append(open, hold)
+					}
+				}
+				holdings := float64(len(rest) * (len(rest) - 1) / 2)
+
+				var hold [2]Card
+				if len(open) > 0 && rng.Float64() < importanceBeatShare {
+					hold = open[rng.Intn(len(open))]
+				} else {
+					a := rng.Intn(len(rest))
+					b := rng.Intn(len(rest) - 1)
+					if b >= a {
+						b++
+					}
+					hold = [2]Card{rest[a], rest[b]}
+				}
+				if len(open) > 0 {
+					q := (1 - importanceBeatShare) / holdings
+					for _, o := range open {
+						if cardMask(o[:]) == cardMask(hold[:]) {
+							q += importanceBeatShare / float64(len(open))
+							break
+						}
+					}
+					ratio /= holdings * q
+				}
+
+				used |= cardMask(hold[:])
+				dealt = append(dealt, hold[0], hold[1])
+				rest = removeCards(rest, hold[:])
+			}
+
+			heroWin, villainWin, tie := playOut(Holdem, dealt, heroHole, community, numOpponents)
+			local.record(heroWin, villainWin, tie)
+			switch {
+			case heroWin:
+				local.HeroWinWeight += ratio
+			case villainWin:
+				local.VillainWinWeight += ratio
+			case tie:
+				local.TieWeight += ratio
+			}
+		}
+	}
+}
+
+// removeCards removes drop from cards in place and returns the shortened
+// slice.
+func removeCards(cards, drop []Card) []Card {
+	out := cards[:0]
+	for _, c := range cards {
+		if cardMask(drop)&cardMask([]Card{c}) == 0 {
+			out = append(out, c)
+		}
+	}
+	return out
+}
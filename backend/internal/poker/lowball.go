@@ -0,0 +1,44 @@
+package poker
+
+// EvaluateBestLowA6 returns the best ace-to-six (London) lowball hand among
+// 5 to 7 cards. Under these rules the lowest hand wins, aces are always
+// high, and pairs, straights and flushes all count against the hand, so the
+// best possible low is 6-4-3-2-A... is not: A-2-3-4-5 is merely ace high
+// and the nuts is 7-5-4-3-2 of mixed suits.
+//
+// The result is an ordinary HandValue ranked by high-hand rules, except
+// that A-2-3-4-5 is never a straight. Compare two lows with CompareLowHands.
+func EvaluateBestLowA6(cards []Card) HandValue {
+	if len(cards) < 5 || len(cards) > 7 {
+		panic("EvaluateBestLowA6 requires 5 to 7 cards")
+	}
+
+	var best HandValue
+	for i, idx := range Combinations(len(cards), 5) {
+		hv := evaluateA6([]Card{cards[idx[0]], cards[idx[1]], cards[idx[2]], cards[idx[3]], cards[idx[4]]})
+		if i == 0 || CompareHandValues(hv, best) < 0 {
+			best = hv
+		}
+	}
+	return best
+}
+
+// CompareLowHands compares two lowball hand values. Returns 1 if a is the
+// better (lower) hand, -1 if b is, and 0 if they tie.
+func CompareLowHands(a, b HandValue) int {
+	return -CompareHandValues(a, b)
+}
+
+// evaluateA6 is evaluate5 with the ace only playing high: the wheel
+// becomes ace-high (or an ace-high flush) instead of a five-high straight.
+func evaluateA6(hand []Card) HandValue {
+	hv := evaluate5(hand)
+	if (hv.Category == Straight || hv.Category == StraightFlush) && hv.Kickers[0] == Five {
+		cat := HighCard
+		if hv.Category == StraightFlush {
+			cat = Flush
+		}
+		return HandValue{Category: cat, Kickers: []Rank{Ace, Five, Four, Three, Two}}
+	}
+	return hv
+}
@@ -0,0 +1,44 @@
+package poker
+
+import "testing"
+
+func TestEvaluateBestLowA6(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    []string
+		category int
+		kickers  []Rank
+	}{
+		{"the nuts", []string{"7c", "5d", "4h", "3s", "2c"}, HighCard, []Rank{Seven, Five, Four, Three, Two}},
+		{"wheel is ace high", []string{"Ac", "5d", "4h", "3s", "2c"}, HighCard, []Rank{Ace, Five, Four, Three, Two}},
+		{"suited wheel is an ace-high flush", []string{"Ah", "5h", "4h", "3h", "2h"}, Flush, []Rank{Ace, Five, Four, Three, Two}},
+		{"six-high straight", []string{"6c", "5d", "4h", "3s", "2c"}, Straight, []Rank{Six}},
+		{"pair dodged with seven cards", []string{"7c", "5d", "4h", "3s", "2c", "Kd", "Kh"}, HighCard, []Rank{Seven, Five, Four, Three, Two}},
+	}
+	for _, tt := range tests {
+		got := EvaluateBestLowA6(mustCards(t, tt.cards...))
+		if want := (HandValue{tt.category, tt.kickers}); !sameHandValue(got, want) {
+			t.Errorf("%s: EvaluateBestLowA6 = %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestCompareLowHands(t *testing.T) {
+	low := func(cards ...string) HandValue { return EvaluateBestLowA6(mustCards(t, cards...)) }
+	tests := []struct {
+		name string
+		a, b HandValue
+		want int
+	}{
+		{"75432 beats the wheel", low("7c", "5d", "4h", "3s", "2c"), low("Ac", "5d", "4h", "3s", "2c"), 1},
+		{"no pair beats a pair", low("8c", "6d", "5h", "4s", "3c"), low("2c", "2d", "3h", "4s", "5c"), 1},
+		{"straights count against", low("8c", "6d", "4h", "3s", "2c"), low("6c", "5d", "4h", "3s", "2c"), 1},
+		{"tie", low("7c", "5d", "4h", "3s", "2c"), low("7d", "5c", "4s", "3h", "2d"), 0},
+		{"higher top card loses", low("9c", "5d", "4h", "3s", "2c"), low("8c", "7d", "6h", "4s", "2c"), -1},
+	}
+	for _, tt := range tests {
+		if got := CompareLowHands(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: CompareLowHands(%v, %v) = %d, want %d", tt.name, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package poker
+
+import "sync"
+
+// HandMatrix is the 13x13 starting hand grid. Rows and columns run from Ace
+// down to Two; pairs sit on the diagonal, suited hands above it (row = high
+// card) and offsuit hands below it (column = high card), so AKs is at
+// [0][1] and AKo at [1][0].
+type HandMatrix struct {
+	Names  [13][13]string
+	Equity [13][13]float64 // heads-up equity (%)
+}
+
+// matrixCell returns the grid position of a starting hand class.
+func matrixCell(h StartingHand) (row, col int) {
+	hi, lo := int(Ace-h.High), int(Ace-h.Low)
+	if h.Suited || h.High == h.Low {
+		return hi, lo
+	}
+	return lo, hi
+}
+
+// StartingHandMatrix returns every starting hand class's heads-up equity
+// against a random hand, taken from the StartingHands table.
+func StartingHandMatrix() HandMatrix {
+	var m HandMatrix
+	for _, h := range StartingHands() {
+		row, col := matrixCell(h)
+		m.Names[row][col] = h.Name
+		m.Equity[row][col] = h.Equity
+	}
+	return m
+}
+
+// maxMatrixCacheEntries bounds the number of range matrices kept by
+// RangeHandMatrix; the cache is cleared when it fills up.
+const maxMatrixCacheEntries = 32
+
+type matrixKey struct {
+	pct    float64
+	trials int
+}
+
+var (
+	matrixMu    sync.Mutex
+	matrixCache = make(map[matrixKey]HandMatrix)
+)
+
+// RangeHandMatrix returns every starting hand class's heads-up equity
+// against TopPercentRange(villainRangePct), simulated with trialsPerHand
+// trials per class. Each class uses a fixed seed, so results are
+// reproducible, and they are cached since a matrix runs 169 simulations.
+// A villainRangePct of 0 or 100 or more is the same as StartingHandMatrix.
+func RangeHandMatrix(villainRangePct float64, trialsPerHand int) HandMatrix {
+	if villainRangePct <= 0 || villainRangePct >= 100 {
+		return StartingHandMatrix()
+	}
+	key := matrixKey{villainRangePct, trialsPerHand}
+	matrixMu.Lock()
+	m, ok := matrixCache[key]
+	matrixMu.Unlock()
+	if ok {
+		return m
+	}
+
+	villainRange := TopPercentRange(villainRangePct)
+	for i, h := range StartingHands() {
+		// Villain's range is suit-symmetric, so any combo stands for the class.
+		hole := h.Combos()[0]
+		res := SimulateEquityWithOptions(hole[:], nil, 1, trialsPerHand, SimulationOptions{VillainRange: villainRange, Seed: int64(i + 1)})
+		heroWin, _, tie := res.Rates()
+		row, col := matrixCell(h)
+		m.Names[row][col] = h.Name
+		m.Equity[row][col] = (heroWin + tie/2) * 100
+	}
+
+	matrixMu.Lock()
+	if len(matrixCache) >= maxMatrixCacheEntries {
+		clear(matrixCache)
+	}
+	matrixCache[key] = m
+	matrixMu.Unlock()
+	return m
+}
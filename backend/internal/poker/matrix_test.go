@@ -0,0 +1,48 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStartingHandMatrix(t *testing.T) {
+	m := StartingHandMatrix()
+	cells := []struct {
+		row, col int
+		name     string
+	}{
+		{0, 0, "AA"}, {0, 1, "AKs"}, {1, 0, "AKo"}, {12, 12, "22"}, {5, 12, "92s"}, {12, 5, "92o"},
+	}
+	for _, c := range cells {
+		if got := m.Names[c.row][c.col]; got != c.name {
+			t.Errorf("Names[%d][%d] = %q, want %q", c.row, c.col, got, c.name)
+		}
+	}
+	for i := range m.Names {
+		for j := range m.Names[i] {
+			if m.Names[i][j] == "" || m.Equity[i][j] <= 0 {
+				t.Errorf("cell [%d][%d] is empty", i, j)
+			}
+		}
+	}
+	if m.Equity[0][0] != 85.1 {
+		t.Errorf("AA equity = %v", m.Equity[0][0])
+	}
+}
+
+func TestRangeHandMatrix(t *testing.T) {
+	if got := RangeHandMatrix(0, 100); !reflect.DeepEqual(got, StartingHandMatrix()) {
+		t.Error("RangeHandMatrix(0) differs from StartingHandMatrix")
+	}
+	m := RangeHandMatrix(10, 200)
+	if m.Names != StartingHandMatrix().Names {
+		t.Error("range matrix names differ from the starting hand matrix")
+	}
+	// AA against a tight range still beats 72o against it.
+	if m.Equity[0][0] <= m.Equity[12][7] {
+		t.Errorf("AA %v <= 72o %v", m.Equity[0][0], m.Equity[12][7])
+	}
+	if again := RangeHandMatrix(10, 200); !reflect.DeepEqual(again, m) {
+		t.Error("seeded range matrix is not reproducible")
+	}
+}
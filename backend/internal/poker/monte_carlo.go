@@ -1,16 +1,156 @@
 package poker
 
 import (
+	"fmt"
 	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // SimulationResult holds the outcome of a Monte Carlo equity simulation.
 type SimulationResult struct {
-	HeroWins   int
+	HeroWins    int
 	VillainWins int
-	Ties       int
-	TrialsRun  int
+	Ties        int
+	TrialsRun   int
+	Method      string // MethodMonteCarlo or MethodExact
+	Seed        int64  // base RNG seed, for replay via SimulateEquityWithSeed
+
+	// Importance-sampled runs also accumulate each outcome's likelihood
+	// ratio here; see SimulationOptions.ImportanceSampling and Rates.
+	HeroWinWeight     float64
+	VillainWinWeight  float64
+	TieWeight         float64
+	ImportanceSampled bool
+
+	// FinishCounts[k] is the number of trials in which exactly k opponents
+	// beat hero (k = 0 includes ties). Only set with
+	// SimulationOptions.TrackFinish.
+	FinishCounts []int
+
+	// WinsAhead is the number of HeroWins in which hero already beat every
+	// opponent on the starting board; the rest of HeroWins came from
+	// improving on a later street. Only set with
+	// SimulationOptions.TrackSources.
+	WinsAhead int
+
+	// ScoreHistogram[b] is the number of trials in which hero's final hand
+	// Score fell in bucket b; see ScoreBucket. Only set with
+	// SimulationOptions.ScoreBuckets.
+	ScoreHistogram []int
+
+	// Samples holds the first trials in deal order, up to
+	// SimulationOptions.SampleTrials.
+	Samples []TrialSample
+}
+
+// TrialSample records the cards and showdown of a single simulated trial.
+type TrialSample struct {
+	Board     []Card   // the completed 5-card board
+	Opponents [][]Card // each opponent's hole cards
+	BeatenBy  int      // opponents with a better hand than hero
+	TiedWith  int      // opponents with a hand equal to hero's
+}
+
+// Rates returns the estimated hero win, villain win and tie probabilities
+// (0-1). For importance-sampled runs the villain win and tie rates are the
+// weighted sums divided by TrialsRun and the hero win rate is the rest, since
+// the sampling is tuned to the loss estimate; otherwise they are plain
+// frequencies.
+func (r SimulationResult) Rates() (heroWin, villainWin, tie float64) {
+	if r.TrialsRun == 0 {
+		return 0, 0, 0
+	}
+	n := float64(r.TrialsRun)
+	if r.ImportanceSampled {
+		villainWin, tie = r.VillainWinWeight/n, r.TieWeight/n
+		return 1 - villainWin - tie, villainWin, tie
+	}
+	return float64(r.HeroWins) / n, float64(r.VillainWins) / n, float64(r.Ties) / n
+}
+
+// Method labels reported in SimulationResult.Method.
+const (
+	MethodMonteCarlo = "monte_carlo"
+	MethodExact      = "exact"
+)
+
+// SimulationOptions tunes SimulateEquityWithOptions. The zero value runs a
+// plain Hold'em simulation with a clock-derived seed.
+type SimulationOptions struct {
+	Game Game // defaults to Holdem
+
+	// Antithetic plays every shuffled deck out twice, once as dealt and once
+	// with each card swapped for its complement. See
+	// SimulateEquityAntithetic.
+	Antithetic bool
+
+	// VillainRange, if non-empty, draws each opponent's hole cards from
+	// these combos instead of the deck. See SimulateEquityVsRange.
+	VillainRange [][2]Card
+
+	// VillainWeights optionally gives each VillainRange combo a weight
+	// (e.g. 0.5 for a combo played half the time); combos are then drawn in
+	// proportion to their weights. See WeightedRange.
+	VillainWeights []float64
+
+	// VillainCards are cards known to be in the first opponent's hand, such
+	// as one exposed card; the rest of that hand is dealt at random. There
+	// must be fewer than Game.HoleCards(). Not supported with Antithetic,
+	// VillainRange or ImportanceSampling.
+	VillainCards []Card
+
+	// ImportanceSampling over-samples board cards and opponent holdings
+	// that beat hero and reweights outcomes to stay unbiased, which cuts
+	// the variance of the loss estimate for strong hands. See
+	// importance.go.
+	ImportanceSampling bool
+
+	// TrackFinish records hero's finishing position in every trial in
+	// SimulationResult.FinishCounts. Not supported with VillainRange or
+	// ImportanceSampling.
+	TrackFinish bool
+
+	// TrackSources splits hero's wins into SimulationResult.WinsAhead and
+	// the rest. Requires Hold'em with a flop, turn or river; not supported
+	// with VillainRange or ImportanceSampling.
+	TrackSources bool
+
+	// ScoreBuckets, if positive, buckets hero's final hand Score into
+	// SimulationResult.ScoreHistogram with this many equal-width buckets.
+	// Not supported with VillainRange or ImportanceSampling.
+	ScoreBuckets int
+
+	// SampleTrials keeps the first SampleTrials trials in
+	// SimulationResult.Samples for debugging. Not supported with
+	// VillainRange or ImportanceSampling.
+	SampleTrials int
+
+	// Workers is the number of goroutines to run trials on; zero means
+	// defaultWorkers(). Results do not depend on it, so Workers: 1 gives a
+	// single-threaded run that is easy to step through in a debugger.
+	Workers int
+
+	// TargetMarginPct, if positive, makes the trial count a maximum: trials
+	// run in batches until the 95% margin of error on hero's win rate is
+	// at most this many percentage points. See SimulateToConfidence.
+	TargetMarginPct float64
+
+	// Progress, if set, is called with the merged result so far after
+	// every batch of trials (see confidenceBatch). Returning false stops
+	// the run, which then returns what it has.
+	Progress func(partial SimulationResult) bool
+
+	// Seed is the base RNG seed. Zero picks one from the clock; the seed
+	// actually used is reported in SimulationResult.Seed.
+	Seed int64
+
+	// NewRNG, if set, creates the generator for each chunk of trials from
+	// its seed, e.g. NewXoshiro256. Nil uses math/rand's default source.
+	NewRNG func(seed int64) RNG
 }
 
 // SimulateEquity estimates the probability that hero's hand wins against
@@ -21,10 +161,57 @@ type SimulationResult struct {
 // numOpponents: number of other players (1+)
 // trials: number of random simulations
 //
-// It uses simple goroutine-based parallelism to split work across CPU cores.
+// It uses simple goroutine-based parallelism to split work across CPU cores,
+// seeded from the clock; the seed is reported in SimulationResult.Seed.
 func SimulateEquity(heroHole []Card, community []Card, numOpponents, trials int) SimulationResult {
-	if len(heroHole) != 2 {
-		panic("heroHole must have length 2")
+	return SimulateEquityWithSeed(heroHole, community, numOpponents, trials, time.Now().UnixNano())
+}
+
+// SimulateEquityWithSeed is like SimulateEquity but derives all of its
+// RNGs from seed: trials run in fixed-size chunks and chunk i gets its own
+// generator seeded with seed+i, whichever worker runs it. The same inputs
+// and seed therefore give an identical SimulationResult regardless of the
+// number of workers. A zero seed picks one from the clock.
+func SimulateEquityWithSeed(heroHole []Card, community []Card, numOpponents, trials int, seed int64) SimulationResult {
+	return SimulateEquityWithOptions(heroHole, community, numOpponents, trials, SimulationOptions{Seed: seed})
+}
+
+// SimulateEquityParallel is like SimulateEquity but runs on exactly
+// workers goroutines (at most one per chunk of trials); zero means one per
+// CPU. Workers pull chunks as they finish, so a slow worker delays the run
+// by at most one chunk.
+func SimulateEquityParallel(heroHole []Card, community []Card, numOpponents, trials, workers int) SimulationResult {
+	return SimulateEquityWithOptions(heroHole, community, numOpponents, trials, SimulationOptions{Workers: workers})
+}
+
+// SimulateGameEquity is like SimulateEquity for any supported Game. Hero and
+// every opponent hold game.HoleCards() cards.
+func SimulateGameEquity(game Game, heroHole []Card, community []Card, numOpponents, trials int) SimulationResult {
+	return SimulateEquityWithOptions(heroHole, community, numOpponents, trials, SimulationOptions{Game: game})
+}
+
+// SimulateEquityAntithetic is like SimulateEquity but uses antithetic
+// variates: every shuffled deck is played out twice, once as dealt and once
+// with each unknown card swapped for its complement (see
+// antitheticComplements), so a runout that helps hero is paired with one
+// that does not. Both halves are uniformly random deals whose outcomes are
+// negatively correlated when hero is drawing, which lowers the variance of
+// the averaged estimate compared with the same number of independent
+// trials. With nothing to draw to, preflop for instance, the gain is small
+// and can turn into a small loss. TrialsRun counts both halves of each pair.
+func SimulateEquityAntithetic(heroHole []Card, community []Card, numOpponents, trials int) SimulationResult {
+	return SimulateEquityWithOptions(heroHole, community, numOpponents, trials, SimulationOptions{Antithetic: true})
+}
+
+// SimulateEquityWithOptions is the general form of SimulateEquity. A villain
+// range can only be combined with Hold'em and without antithetic sampling.
+func SimulateEquityWithOptions(heroHole []Card, community []Card, numOpponents, trials int, opts SimulationOptions) SimulationResult {
+	game := opts.Game
+	if game == "" {
+		game = Holdem
+	}
+	if len(heroHole) != game.HoleCards() {
+		panic(fmt.Sprintf("heroHole must have length %d", game.HoleCards()))
 	}
 	if len(community) != 0 && len(community) != 3 && len(community) != 4 && len(community) != 5 {
 		panic("community must be 0, 3, 4, or 5 cards")
@@ -32,81 +219,301 @@ func SimulateEquity(heroHole []Card, community []Card, numOpponents, trials int)
 	if numOpponents < 1 {
 		panic("numOpponents must be >= 1")
 	}
+	if len(opts.VillainRange) > 0 && (game != Holdem || opts.Antithetic) {
+		panic("villain ranges require holdem without antithetic sampling")
+	}
+	if opts.VillainWeights != nil && len(opts.VillainWeights) != len(opts.VillainRange) {
+		panic("VillainWeights must have one weight per VillainRange combo")
+	}
+	if opts.ImportanceSampling && (game != Holdem || opts.Antithetic || len(opts.VillainRange) > 0) {
+		panic("importance sampling requires holdem without antithetic sampling or villain ranges")
+	}
+	if len(opts.VillainCards) > 0 && (len(opts.VillainCards) >= game.HoleCards() || opts.Antithetic || len(opts.VillainRange) > 0 || opts.ImportanceSampling) {
+		panic("villain cards must be fewer than a full hand and are not supported with antithetic sampling, villain ranges or importance sampling")
+	}
+	if len(opts.VillainCards) > 0 && HasDuplicates(append(append(append([]Card{}, heroHole...), community...), opts.VillainCards...)) {
+		panic("villain cards must not repeat each other or hero or community cards")
+	}
+	if opts.TrackFinish && (len(opts.VillainRange) > 0 || opts.ImportanceSampling) {
+		panic("finish tracking is not supported with villain ranges or importance sampling")
+	}
+	if opts.TrackSources && (game != Holdem || len(community) < 3 || len(opts.VillainRange) > 0 || opts.ImportanceSampling) {
+		panic("source tracking requires holdem with a flop, turn or river and no villain range or importance sampling")
+	}
+	if opts.ScoreBuckets > 0 && (len(opts.VillainRange) > 0 || opts.ImportanceSampling) {
+		panic("score histograms are not supported with villain ranges or importance sampling")
+	}
+	if opts.SampleTrials > 0 && (len(opts.VillainRange) > 0 || opts.ImportanceSampling) {
+		panic("trial samples are not supported with villain ranges or importance sampling")
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
 	if trials <= 0 {
-		return SimulationResult{}
+		return SimulationResult{Method: MethodMonteCarlo, Seed: seed}
 	}
 
+	var work func(rng *rand.Rand, local *SimulationResult, n int)
+	switch {
+	case len(opts.VillainRange) > 0:
+		work = rangeWorker(heroHole, community, newComboSampler(opts.VillainRange, opts.VillainWeights), numOpponents)
+	case opts.ImportanceSampling:
+		work = importanceWorker(heroHole, community, remainingDeck(heroHole, community), numOpponents)
+	default:
+		work = dealWorker(game, heroHole, community, numOpponents, opts)
+	}
+
+	if opts.TargetMarginPct > 0 || opts.Progress != nil {
+		return runBatched(seed, trials, opts, work)
+	}
+	return runParallel(seed, trials, opts.Workers, opts.NewRNG, work)
+}
+
+// dealWorker plays out trials from a shuffled deck, handling the
+// Antithetic, VillainCards, TrackFinish, TrackSources, ScoreBuckets and
+// SampleTrials options.
+func dealWorker(game Game, heroHole []Card, community []Card, numOpponents int, opts SimulationOptions) func(*rand.Rand, *SimulationResult, int) {
 	// Build deck without known cards.
-	deck := FullDeck()
-	used := make(map[string]bool)
-	for _, c := range heroHole {
-		used[c.Str] = true
+	filtered := remainingDeck(heroHole, community, opts.VillainCards)
+	var complements [52]Card
+	if opts.Antithetic {
+		complements = antitheticComplements(game, filtered, heroHole, community)
 	}
-	for _, c := range community {
-		used[c.Str] = true
+	var heroNow HandValue
+	if opts.TrackSources {
+		heroNow = EvaluateBestHand(append(append([]Card{}, heroHole...), community...))
 	}
-	filtered := make([]Card, 0, len(deck))
-	for _, c := range deck {
-		if !used[c.Str] {
-			filtered = append(filtered, c)
+
+	return func(rng *rand.Rand, local *SimulationResult, n int) {
+		tmp := make([]Card, len(filtered)+len(opts.VillainCards))
+		if opts.TrackFinish {
+			local.FinishCounts = make([]int, numOpponents+1)
+		}
+		if opts.ScoreBuckets > 0 {
+			local.ScoreHistogram = make([]int, opts.ScoreBuckets)
+		}
+		deal := func() {
+			beatenBy, tiedWith := playOutCounts(game, tmp, heroHole, community, numOpponents)
+			local.record(showdownOutcome(beatenBy, tiedWith))
+			if opts.TrackFinish {
+				local.FinishCounts[beatenBy]++
+			}
+			if opts.ScoreBuckets > 0 {
+				board := append(append([]Card{}, community...), tmp[:5-len(community)]...)
+				local.ScoreHistogram[ScoreBucket(game.BestHand(heroHole, board).Score(), opts.ScoreBuckets)]++
+			}
+			if opts.TrackSources && beatenBy == 0 && tiedWith == 0 && aheadOnBoard(heroNow, tmp, community, numOpponents) {
+				local.WinsAhead++
+			}
+			if len(local.Samples) < opts.SampleTrials {
+				local.Samples = append(local.Samples, sampleTrial(game, tmp, community, numOpponents, beatenBy, tiedWith))
+			}
+		}
+		for local.TrialsRun < n {
+			shuffleInto(rng, tmp[:len(filtered)], filtered)
+			placeVillainCards(tmp, len(filtered), 5-len(community), opts.VillainCards)
+			deal()
+			if opts.Antithetic && local.TrialsRun < n {
+				for i, c := range tmp[:len(filtered)] {
+					tmp[i] = complements[c.index()]
+				}
+				deal()
+			}
 		}
 	}
+}
+
+// defaultWorkers returns the number of worker goroutines used when
+// SimulationOptions.Workers is zero: one per CPU the Go scheduler may use
+// (GOMAXPROCS), so a small container is not oversubscribed and a large
+// machine is kept busy.
+func defaultWorkers() int {
+	return runtime.GOMAXPROCS(0)
+}
 
-	// Parallelism: number of worker goroutines.
-	workers := 4
-	if trials < workers {
-		workers = trials
+// chunkTrials is the number of trials in each unit of work handed to a
+// worker. It is even so antithetic pairs never straddle two chunks.
+const chunkTrials = 2500
+
+// runParallel splits trials into fixed-size chunks, runs them on workers
+// goroutines (defaultWorkers() if zero) and merges their results. Chunk i's
+// RNG, from newRNG (see newRand), is seeded with seed+i and chunks are
+// merged in order, so a given seed and trial count reproduce the same
+// result whatever the number of workers. work must run n trials into local.
+func runParallel(seed int64, trials, workers int, newRNG func(int64) RNG, work func(rng *rand.Rand, local *SimulationResult, n int)) SimulationResult {
+	chunks := (trials + chunkTrials - 1) / chunkTrials
+	if workers <= 0 {
+		workers = defaultWorkers()
+	}
+	if workers > chunks {
+		workers = chunks
 	}
-	trialsPerWorker := trials / workers
-	remaining := trials % workers
 
-	results := make(chan SimulationResult, workers)
+	results := make([]SimulationResult, chunks)
+	var next int64 = -1
+	var wg sync.WaitGroup
 
 	for w := 0; w < workers; w++ {
-		tw := trialsPerWorker
-		if w == 0 {
-			tw += remaining
-		}
-		go func(trialsForWorker int) {
-			rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-			local := SimulationResult{}
-
-			for i := 0; i < trialsForWorker; i++ {
-				heroWin, villainWin, tie := simulateOnce(rng, heroHole, community, filtered, numOpponents)
-				if heroWin {
-					local.HeroWins++
-				} else if villainWin {
-					local.VillainWins++
-				} else if tie {
-					local.Ties++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= chunks {
+					return
+				}
+				n := chunkTrials
+				if i == chunks-1 {
+					n = trials - i*chunkTrials
 				}
-				local.TrialsRun++
+				rng := newRand(newRNG, seed+int64(i))
+				work(rng, &results[i], n)
 			}
-
-			results <- local
-		}(tw)
+		}()
 	}
+	wg.Wait()
 
-	final := SimulationResult{}
-	for i := 0; i < workers; i++ {
-		r := <-results
-		final.HeroWins += r.HeroWins
-		final.VillainWins += r.VillainWins
-		final.Ties += r.Ties
-		final.TrialsRun += r.TrialsRun
+	final := MergeResults(results...)
+	final.Method = MethodMonteCarlo
+	final.Seed = seed
+	if len(results) > 0 {
+		// Every chunk keeps its own first samples; only the overall
+		// first ones are wanted.
+		final.Samples = final.Samples[:min(len(final.Samples), len(results[0].Samples))]
 	}
 
 	return final
 }
 
-func simulateOnce(rng *rand.Rand, heroHole []Card, community []Card, deck []Card, numOpponents int) (heroWin, villainWin, tie bool) {
-	// Make a copy of deck for shuffling.
-	tmp := make([]Card, len(deck))
-	copy(tmp, deck)
-	rng.Shuffle(len(tmp), func(i, j int) {
-		tmp[i], tmp[j] = tmp[j], tmp[i]
+// MergeResults combines partial results, e.g. from separate processes or
+// requests, by summing their counts and weights. FinishCounts and
+// ScoreHistogram are summed position by position and Samples concatenated.
+// Method and Seed are taken from the first result.
+func MergeResults(results ...SimulationResult) SimulationResult {
+	var out SimulationResult
+	for i, r := range results {
+		if i == 0 {
+			out.Method, out.Seed = r.Method, r.Seed
+		}
+		out.merge(r)
+	}
+	return out
+}
+
+// merge adds o's counts, weights and samples to r.
+func (r *SimulationResult) merge(o SimulationResult) {
+	r.HeroWins += o.HeroWins
+	r.VillainWins += o.VillainWins
+	r.Ties += o.Ties
+	r.TrialsRun += o.TrialsRun
+	r.HeroWinWeight += o.HeroWinWeight
+	r.VillainWinWeight += o.VillainWinWeight
+	r.TieWeight += o.TieWeight
+	r.ImportanceSampled = r.ImportanceSampled || o.ImportanceSampled
+	if len(o.FinishCounts) > len(r.FinishCounts) {
+		r.FinishCounts = append(r.FinishCounts, make([]int, len(o.FinishCounts)-len(r.FinishCounts))...)
+	}
+	for k, c := range o.FinishCounts {
+		r.FinishCounts[k] += c
+	}
+	if len(o.ScoreHistogram) > len(r.ScoreHistogram) {
+		r.ScoreHistogram = append(r.ScoreHistogram, make([]int, len(o.ScoreHistogram)-len(r.ScoreHistogram))...)
+	}
+	for b, c := range o.ScoreHistogram {
+		r.ScoreHistogram[b] += c
+	}
+	r.WinsAhead += o.WinsAhead
+	r.Samples = append(r.Samples, o.Samples...)
+}
+
+// scoreLimit is one more than the largest possible HandValue.Score.
+const scoreLimit = (StraightFlush + 1) << 20
+
+// ScoreBucket returns which of buckets equal-width buckets, covering every
+// possible HandValue.Score from weakest to strongest, holds score. With 9
+// buckets each hand category gets its own.
+func ScoreBucket(score, buckets int) int {
+	return score * buckets / scoreLimit
+}
+
+// record adds a single trial outcome to r.
+func (r *SimulationResult) record(heroWin, villainWin, tie bool) {
+	if heroWin {
+		r.HeroWins++
+	} else if villainWin {
+		r.VillainWins++
+	} else if tie {
+		r.Ties++
+	}
+	r.TrialsRun++
+}
+
+// shuffleInto copies deck into dst and shuffles dst in place.
+func shuffleInto(rng *rand.Rand, dst, deck []Card) {
+	copy(dst, deck)
+	rng.Shuffle(len(dst), func(i, j int) {
+		dst[i], dst[j] = dst[j], dst[i]
 	})
+}
 
+// placeVillainCards puts the known villain cards where playOutCounts deals
+// the first opponent's hand, at tmp[start:], moving the shuffled cards they
+// replace to the spare slots from tmp[n:].
+func placeVillainCards(tmp []Card, n, start int, known []Card) {
+	for i, c := range known {
+		tmp[n+i] = tmp[start+i]
+		tmp[start+i] = c
+	}
+}
+
+// antitheticComplements pairs up the cards of deck for antithetic
+// sampling. The cards are ordered by how much they would help hero: on a
+// Hold'em flop or turn by hero's hand with the card added to the board,
+// otherwise by whether the card pairs one of hero's hole cards; ties go to
+// the lower rank, which is less use to an opponent. The i-th card from the
+// top then maps to the i-th from the bottom and back, so hero's best out is
+// swapped for the biggest blank. The result is indexed by Card.index(). As
+// the mapping is a bijection on deck, it turns a uniformly shuffled deal
+// into another uniformly shuffled deal.
+func antitheticComplements(game Game, deck, heroHole, community []Card) [52]Card {
+	var keys [52]int
+	for _, c := range deck {
+		k := int(Ace - c.Rank)
+		if game == Holdem && len(community) >= 3 && len(community) < 5 {
+			board := append(append([]Card{}, community...), c)
+			k += game.BestHand(heroHole, board).Score() << 4
+		} else {
+			for _, h := range heroHole {
+				if h.Rank == c.Rank {
+					k += 1 << 4
+				}
+			}
+		}
+		keys[c.index()] = k
+	}
+
+	sorted := append([]Card(nil), deck...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return keys[sorted[i].index()] > keys[sorted[j].index()]
+	})
+	var out [52]Card
+	for i, c := range sorted {
+		out[c.index()] = sorted[len(sorted)-1-i]
+	}
+	return out
+}
+
+// playOut deals the missing community cards and opponent holdings from the
+// top of an already shuffled deck and reports hero's showdown outcome.
+func playOut(game Game, tmp []Card, heroHole []Card, community []Card, numOpponents int) (heroWin, villainWin, tie bool) {
+	return showdownOutcome(playOutCounts(game, tmp, heroHole, community, numOpponents))
+}
+
+// playOutCounts is like playOut but reports how many opponents beat hero
+// and how many tie with hero.
+func playOutCounts(game Game, tmp []Card, heroHole []Card, community []Card, numOpponents int) (beatenBy, tiedWith int) {
 	// Determine how many more community cards we need to draw.
 	toDraw := 5 - len(community)
 	drawIdx := 0
@@ -118,40 +525,69 @@ func simulateOnce(rng *rand.Rand, heroHole []Card, community []Card, deck []Card
 		drawIdx++
 	}
 
-	// Hero 7-card hand.
-	heroSeven := append([]Card{}, heroHole...)
-	heroSeven = append(heroSeven, simCommunity...)
-	heroBest := EvaluateBestHand(heroSeven)
+	heroBest := game.BestHand(heroHole, simCommunity)
 
 	// Opponents.
-	villainBetter := false
-	equalCount := 0
-
 	for opp := 0; opp < numOpponents; opp++ {
-		if drawIdx+2 > len(tmp) {
+		n := game.HoleCards()
+		if drawIdx+n > len(tmp) {
 			// Defensive; should not happen if deck is sized correctly.
 			break
 		}
-		oppHole := []Card{tmp[drawIdx], tmp[drawIdx+1]}
-		drawIdx += 2
+		oppHole := tmp[drawIdx : drawIdx+n]
+		drawIdx += n
 
-		oppSeven := append([]Card{}, oppHole...)
-		oppSeven = append(oppSeven, simCommunity...)
-		oppBest := EvaluateBestHand(oppSeven)
+		oppBest := game.BestHand(oppHole, simCommunity)
 
 		cmp := CompareHandValues(oppBest, heroBest)
 		if cmp > 0 {
-			villainBetter = true
+			beatenBy++
 		} else if cmp == 0 {
-			equalCount++
+			tiedWith++
+		}
+	}
+
+	return beatenBy, tiedWith
+}
+
+// aheadOnBoard reports whether heroNow, hero's hand on the starting
+// community cards, beats every Hold'em opponent hand dealt from tmp by
+// playOutCounts on those same community cards.
+func aheadOnBoard(heroNow HandValue, tmp []Card, community []Card, numOpponents int) bool {
+	drawIdx := 5 - len(community)
+	for opp := 0; opp < numOpponents; opp++ {
+		oppNow := EvaluateBestHand(append(append([]Card{}, tmp[drawIdx:drawIdx+2]...), community...))
+		if CompareHandValues(oppNow, heroNow) >= 0 {
+			return false
 		}
+		drawIdx += 2
 	}
+	return true
+}
+
+// sampleTrial copies the cards playOutCounts dealt from tmp into a
+// TrialSample.
+func sampleTrial(game Game, tmp []Card, community []Card, numOpponents, beatenBy, tiedWith int) TrialSample {
+	toDraw := 5 - len(community)
+	board := append([]Card{}, community...)
+	board = append(board, tmp[:toDraw]...)
+
+	s := TrialSample{Board: board, BeatenBy: beatenBy, TiedWith: tiedWith}
+	n := game.HoleCards()
+	for opp := 0; opp < numOpponents; opp++ {
+		start := toDraw + opp*n
+		s.Opponents = append(s.Opponents, append([]Card{}, tmp[start:start+n]...))
+	}
+	return s
+}
 
-	if villainBetter {
+// showdownOutcome turns opponent counts into hero's win/lose/tie result.
+func showdownOutcome(beatenBy, tiedWith int) (heroWin, villainWin, tie bool) {
+	if beatenBy > 0 {
 		return false, true, false
 	}
 
-	if equalCount > 0 {
+	if tiedWith > 0 {
 		return false, false, true
 	}
 
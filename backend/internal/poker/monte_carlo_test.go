@@ -0,0 +1,345 @@
+package poker
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestSimulateEquityParallelRunsRequestedTrials(t *testing.T) {
+	hole := mustCards(t, "Ah", "As")
+	for _, workers := range []int{0, 1, 3, 7, 64} {
+		for _, trials := range []int{1, 2499, 2500, 2501, 10007} {
+			res := SimulateEquityParallel(hole, nil, 2, trials, workers)
+			if res.TrialsRun != trials {
+				t.Errorf("workers=%d trials=%d: TrialsRun = %d", workers, trials, res.TrialsRun)
+			}
+			if got := res.HeroWins + res.VillainWins + res.Ties; got != trials {
+				t.Errorf("workers=%d trials=%d: outcomes sum to %d", workers, trials, got)
+			}
+		}
+	}
+}
+
+func TestSeededSimulationIgnoresWorkerCount(t *testing.T) {
+	hole := mustCards(t, "Kh", "Qh")
+	community := mustCards(t, "Jh", "Th", "2c")
+	want := SimulateEquityWithOptions(hole, community, 3, 12345, SimulationOptions{Seed: 42, Workers: 1})
+	for _, workers := range []int{0, 2, 5, 16} {
+		got := SimulateEquityWithOptions(hole, community, 3, 12345, SimulationOptions{Seed: 42, Workers: workers})
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("workers=%d: got %+v, want %+v", workers, got, want)
+		}
+	}
+}
+
+func TestDefaultWorkersIsPositive(t *testing.T) {
+	if n := defaultWorkers(); n < 1 {
+		t.Fatalf("defaultWorkers() = %d", n)
+	}
+}
+
+func TestSimulateEquityWithSeedReplays(t *testing.T) {
+	hole := mustCards(t, "Ah", "Kd")
+	a := SimulateEquityWithSeed(hole, nil, 2, 5000, 77)
+	b := SimulateEquityWithSeed(hole, nil, 2, 5000, 77)
+	if !reflect.DeepEqual(a, b) || a.Seed != 77 {
+		t.Errorf("seed 77: %+v vs %+v", a, b)
+	}
+	res := SimulateEquity(hole, nil, 1, 1000)
+	if res.Seed == 0 || res.Method != MethodMonteCarlo {
+		t.Errorf("clock seed: %+v", res)
+	}
+	if replay := SimulateEquityWithSeed(hole, nil, 1, 1000, res.Seed); !reflect.DeepEqual(replay, res) {
+		t.Errorf("replaying clock seed %d: %+v vs %+v", res.Seed, replay, res)
+	}
+	if res := SimulateEquityWithSeed(hole, nil, 1, 0, 5); res.TrialsRun != 0 || res.Seed != 5 {
+		t.Errorf("no trials: %+v", res)
+	}
+}
+
+func TestSimulateEquityWithOptionsPanics(t *testing.T) {
+	aa := mustCards(t, "Ah", "Ad")
+	flop := mustCards(t, "2c", "7d", "9h")
+	tests := []struct {
+		name            string
+		hole, community []Card
+		opponents       int
+	}{
+		{"one hole card", aa[:1], nil, 1},
+		{"two-card board", aa, flop[:2], 1},
+		{"no opponents", aa, nil, 0},
+	}
+	for _, tt := range tests {
+		mustPanic(t, tt.name, func() {
+			SimulateEquityWithOptions(tt.hole, tt.community, tt.opponents, 10, SimulationOptions{})
+		})
+	}
+}
+
+func TestSimulateGameEquityOmaha(t *testing.T) {
+	res := SimulateGameEquity(Omaha, mustCards(t, "Ah", "Ad", "Kh", "Kd"), nil, 1, 2000)
+	if win, _, _ := res.Rates(); res.TrialsRun != 2000 || win < 0.55 {
+		t.Errorf("AAKK double-suited: %+v", res)
+	}
+}
+
+func TestAntitheticMatchesPlainSimulation(t *testing.T) {
+	plain, _, _ := simulateAcesOnFlop(t, SimulationOptions{}).Rates()
+	if win, _, _ := simulateAcesOnFlop(t, SimulationOptions{Antithetic: true}).Rates(); math.Abs(win-plain) > 0.04 {
+		t.Errorf("antithetic win rate %v, plain %v", win, plain)
+	}
+}
+
+// rateVariances returns the sample variances of hero's estimated win and
+// loss rates over runs simulations seeded 1 to runs.
+func rateVariances(hero, board []Card, numOpponents, trials, runs int, opts SimulationOptions) (win, loss float64) {
+	wins, losses := make([]float64, runs), make([]float64, runs)
+	for i := range wins {
+		opts.Seed = int64(i + 1)
+		wins[i], losses[i], _ = SimulateEquityWithOptions(hero, board, numOpponents, trials, opts).Rates()
+	}
+	return sampleVariance(wins), sampleVariance(losses)
+}
+
+func sampleVariance(xs []float64) float64 {
+	mean := 0.0
+	for _, x := range xs {
+		mean += x / float64(len(xs))
+	}
+	variance := 0.0
+	for _, x := range xs {
+		variance += (x - mean) * (x - mean) / float64(len(xs)-1)
+	}
+	return variance
+}
+
+func TestAntitheticLowersVarianceOnADraw(t *testing.T) {
+	// A flush and open-ended straight draw on the turn, so most pairs match
+	// an out with a blank.
+	hero, board := mustCards(t, "5h", "4h"), mustCards(t, "7h", "6c", "2h", "Jd")
+	plain, _ := rateVariances(hero, board, 1, 200, 200, SimulationOptions{})
+	anti, _ := rateVariances(hero, board, 1, 200, 200, SimulationOptions{Antithetic: true})
+	if anti >= 0.8*plain {
+		t.Errorf("antithetic variance %v, plain %v", anti, plain)
+	}
+}
+
+func TestAntitheticComplementsArePaired(t *testing.T) {
+	hero, board := mustCards(t, "Ah", "Kh"), mustCards(t, "Qh", "7c", "2h")
+	deck := remainingDeck(hero, board)
+	for _, community := range [][]Card{nil, board} {
+		comp := antitheticComplements(Holdem, deck, hero, community)
+		seen := map[int]bool{}
+		for _, c := range deck {
+			m := comp[c.index()]
+			if seen[m.index()] || comp[m.index()].index() != c.index() {
+				t.Fatalf("%v maps to %v, which maps back to %v", c, m, comp[m.index()])
+			}
+			seen[m.index()] = true
+		}
+	}
+	// Hero's best card on this flop, the jack of hearts for the top flush,
+	// pairs with a blank.
+	comp := antitheticComplements(Holdem, deck, hero, board)
+	if got := comp[mustCards(t, "Jh")[0].index()]; got.Rank == Jack || got.Suit == Hearts {
+		t.Errorf("Jh pairs with %v", got)
+	}
+}
+
+func TestImportanceSamplingMatchesPlainSimulation(t *testing.T) {
+	plain, _, _ := simulateAcesOnFlop(t, SimulationOptions{}).Rates()
+	if win, _, _ := simulateAcesOnFlop(t, SimulationOptions{ImportanceSampling: true}).Rates(); math.Abs(win-plain) > 0.04 {
+		t.Errorf("importance sampling win rate %v, plain %v", win, plain)
+	}
+}
+
+func TestImportanceSamplingLowersLossVariance(t *testing.T) {
+	// Top set on a dry flop loses only to runner-runner hands.
+	hero, board := mustCards(t, "Ah", "Ad"), mustCards(t, "Ac", "7d", "2s")
+	_, plain := rateVariances(hero, board, 2, 500, 40, SimulationOptions{})
+	_, weighted := rateVariances(hero, board, 2, 500, 40, SimulationOptions{ImportanceSampling: true})
+	if weighted >= 0.5*plain {
+		t.Errorf("loss rate variance %v with importance sampling, %v without", weighted, plain)
+	}
+}
+
+func TestTrackFinish(t *testing.T) {
+	const trials = 3000
+	res := SimulateEquityWithOptions(mustCards(t, "Ah", "Ad"), acesFlop(t), 3, trials, SimulationOptions{Seed: 3, TrackFinish: true})
+	if len(res.FinishCounts) != 4 || sumInts(res.FinishCounts) != trials || res.FinishCounts[0] != res.HeroWins+res.Ties {
+		t.Errorf("FinishCounts = %v", res.FinishCounts)
+	}
+	kk := [][2]Card{{mustCards(t, "Kh")[0], mustCards(t, "Kd")[0]}}
+	mustPanic(t, "finish with a range", func() {
+		SimulateEquityWithOptions(mustCards(t, "Ah", "Ad"), nil, 1, 10, SimulationOptions{VillainRange: kk, TrackFinish: true})
+	})
+}
+
+func TestSampleTrials(t *testing.T) {
+	res := simulateAcesOnFlop(t, SimulationOptions{SampleTrials: 3})
+	if len(res.Samples) != 3 {
+		t.Fatalf("got %d samples", len(res.Samples))
+	}
+	for _, s := range res.Samples {
+		if len(s.Board) != 5 || len(s.Opponents) != 2 || CanonicalKey(s.Board[:3]) != CanonicalKey(acesFlop(t)) {
+			t.Errorf("sample %+v", s)
+		}
+	}
+}
+
+func TestMergeResults(t *testing.T) {
+	a := SimulationResult{HeroWins: 3, VillainWins: 1, Ties: 1, TrialsRun: 5, Method: MethodMonteCarlo, Seed: 9, FinishCounts: []int{4, 1}, WinsAhead: 2}
+	b := SimulationResult{HeroWins: 1, VillainWins: 2, TrialsRun: 3, Method: MethodExact, Seed: 4, FinishCounts: []int{1, 1, 1}, ScoreHistogram: []int{3}}
+	want := SimulationResult{HeroWins: 4, VillainWins: 3, Ties: 1, TrialsRun: 8, Method: MethodMonteCarlo, Seed: 9, FinishCounts: []int{5, 2, 1}, WinsAhead: 2, ScoreHistogram: []int{3}}
+	if got := MergeResults(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeResults = %+v, want %+v", got, want)
+	}
+	if got := MergeResults(); !reflect.DeepEqual(got, SimulationResult{}) {
+		t.Errorf("MergeResults() = %+v", got)
+	}
+}
+
+func TestProgressCanStopARun(t *testing.T) {
+	calls := 0
+	res := SimulateEquityWithOptions(mustCards(t, "Ah", "Kd"), nil, 1, 10*confidenceBatch, SimulationOptions{
+		Seed: 1,
+		Progress: func(partial SimulationResult) bool {
+			calls++
+			return partial.TrialsRun < 2*confidenceBatch
+		},
+	})
+	if calls != 2 || res.TrialsRun != 2*confidenceBatch {
+		t.Errorf("%d progress calls, %d trials", calls, res.TrialsRun)
+	}
+}
+
+func TestTrackSources(t *testing.T) {
+	// An overpair on a dry flop wins mostly by staying ahead.
+	res := simulateAcesOnFlop(t, SimulationOptions{TrackSources: true})
+	if res.WinsAhead <= res.HeroWins/2 || res.WinsAhead > res.HeroWins {
+		t.Errorf("WinsAhead = %d of %d wins", res.WinsAhead, res.HeroWins)
+	}
+	mustPanic(t, "sources preflop", func() {
+		SimulateEquityWithOptions(mustCards(t, "Ah", "Ad"), nil, 1, 10, SimulationOptions{TrackSources: true})
+	})
+}
+
+func TestVillainWeightsMustMatchRange(t *testing.T) {
+	kk := [][2]Card{{mustCards(t, "Kh")[0], mustCards(t, "Kd")[0]}}
+	mustPanic(t, "weights mismatch", func() {
+		SimulateEquityWithOptions(mustCards(t, "Ah", "Ad"), nil, 1, 10, SimulationOptions{VillainRange: kk, VillainWeights: []float64{1, 1}})
+	})
+}
+
+func TestVillainCards(t *testing.T) {
+	// Villain holding a seven has hero's aces in worse shape.
+	plain, _, _ := simulateAcesOnFlop(t, SimulationOptions{}).Rates()
+	if win, _, _ := simulateAcesOnFlop(t, SimulationOptions{VillainCards: mustCards(t, "7c")}).Rates(); win >= plain {
+		t.Errorf("villain with a seven: win rate %v, plain %v", win, plain)
+	}
+
+	aa, flop := mustCards(t, "Ah", "Ad"), acesFlop(t)
+	tests := []struct {
+		name      string
+		community []Card
+		villain   []Card
+	}{
+		{"villain holds a full hand", nil, mustCards(t, "Kh", "Kd")},
+		{"villain card repeats hero's", nil, aa[:1]},
+		{"villain card on the board", flop, flop[:1]},
+	}
+	for _, tt := range tests {
+		mustPanic(t, tt.name, func() {
+			SimulateEquityWithOptions(aa, tt.community, 1, 10, SimulationOptions{VillainCards: tt.villain})
+		})
+	}
+}
+
+func TestScoreHistogram(t *testing.T) {
+	res := simulateAcesOnFlop(t, SimulationOptions{ScoreBuckets: 9})
+	if len(res.ScoreHistogram) != 9 || sumInts(res.ScoreHistogram) != res.TrialsRun || res.ScoreHistogram[HighCard] != 0 {
+		t.Errorf("ScoreHistogram = %v", res.ScoreHistogram)
+	}
+}
+
+func TestScoreBucket(t *testing.T) {
+	for _, cards := range [][]string{
+		{"7h", "5d", "4c", "3s", "2h"},
+		{"Ah", "Ad", "Kc", "Qs", "Jh"},
+		{"Ah", "Kh", "Qh", "Jh", "9h"},
+		{"Ah", "Kh", "Qh", "Jh", "Th"},
+	} {
+		hv := EvaluateBestHand(mustCards(t, cards...))
+		if got := ScoreBucket(hv.Score(), 9); got != hv.Category {
+			t.Errorf("ScoreBucket(%v, 9) = %d, want %d", hv, got, hv.Category)
+		}
+		if got := ScoreBucket(hv.Score(), 1); got != 0 {
+			t.Errorf("ScoreBucket(%v, 1) = %d", hv, got)
+		}
+	}
+}
+
+// acesFlop is the dry flop the option tests deal pocket aces against.
+func acesFlop(t testing.TB) []Card {
+	return mustCards(t, "2c", "7d", "9h")
+}
+
+// simulateAcesOnFlop runs a seeded 3000-trial simulation of pocket aces on
+// acesFlop against two opponents with opts.
+func simulateAcesOnFlop(t *testing.T, opts SimulationOptions) SimulationResult {
+	t.Helper()
+	const trials = 3000
+	opts.Seed = 3
+	res := SimulateEquityWithOptions(mustCards(t, "Ah", "Ad"), acesFlop(t), 2, trials, opts)
+	if res.TrialsRun != trials || res.HeroWins+res.VillainWins+res.Ties != trials {
+		t.Errorf("%+v: ran %d trials", opts, res.TrialsRun)
+	}
+	return res
+}
+
+func sumInts(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		n += x
+	}
+	return n
+}
+
+// mustPanic fails the test unless f panics.
+func mustPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: no panic", name)
+		}
+	}()
+	f()
+}
+
+func BenchmarkSimulateEquity(b *testing.B) {
+	hole := mustCards(b, "Ah", "Kd")
+	for i := 0; i < b.N; i++ {
+		SimulateEquityWithSeed(hole, nil, 3, 10000, 1)
+	}
+}
+
+// BenchmarkSimulateEquityWorkers shows how the simulation scales with the
+// number of workers.
+func BenchmarkSimulateEquityWorkers(b *testing.B) {
+	hole := mustCards(b, "Ah", "Kd")
+	counts := []int{1, 2, 4}
+	if n := runtime.NumCPU(); !slices.Contains(counts, n) {
+		counts = append(counts, n)
+	}
+	for _, workers := range counts {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				SimulateEquityWithOptions(hole, nil, 3, 10000, SimulationOptions{Seed: 1, Workers: workers})
+			}
+		})
+	}
+}
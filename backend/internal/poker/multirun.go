@@ -0,0 +1,75 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// BoardRun is one of the boards dealt by RunItMultiple.
+type BoardRun struct {
+	Board  []Card // the complete 5-card board
+	Result int    // 1 if hero wins this run, -1 if villain does, 0 on a split
+}
+
+// MultiRunResult is the outcome of running the board several times.
+type MultiRunResult struct {
+	Runs         []BoardRun
+	HeroShare    float64 // fraction (0-1) of the pot hero wins over all runs
+	VillainShare float64
+	Seed         int64
+}
+
+// RunItMultiple deals the rest of the board runs times for two all-in
+// players, as when "running it twice". The deck is shuffled once from seed
+// (zero picks one from the clock) and each run takes its cards from the
+// next disjoint portion of it, so no card appears on two runs. Every run is
+// worth an equal part of the pot, split in half on a tie. It returns an
+// error if the deck is too small for that many runs.
+func RunItMultiple(hero, villain, community []Card, runs int, seed int64) (MultiRunResult, error) {
+	if len(hero) != 2 || len(villain) != 2 {
+		return MultiRunResult{}, fmt.Errorf("hole cards must have length 2")
+	}
+	if len(community) != 0 && len(community) != 3 && len(community) != 4 && len(community) != 5 {
+		return MultiRunResult{}, fmt.Errorf("community must be 0, 3, 4, or 5 cards")
+	}
+	deck := remainingDeck(hero, villain, community)
+	toDraw := 5 - len(community)
+	if runs < 1 || runs > maxRuns(len(deck), toDraw) {
+		return MultiRunResult{}, fmt.Errorf("runs must be between 1 and %d for this board", maxRuns(len(deck), toDraw))
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+
+	res := MultiRunResult{Seed: seed}
+	for i := 0; i < runs; i++ {
+		board := append(append([]Card{}, community...), deck[i*toDraw:(i+1)*toDraw]...)
+		run := BoardRun{Board: board}
+		switch cmp := CompareHandValues(Holdem.BestHand(hero, board), Holdem.BestHand(villain, board)); {
+		case cmp > 0:
+			run.Result = 1
+			res.HeroShare += 1 / float64(runs)
+		case cmp < 0:
+			run.Result = -1
+			res.VillainShare += 1 / float64(runs)
+		default:
+			res.HeroShare += 0.5 / float64(runs)
+			res.VillainShare += 0.5 / float64(runs)
+		}
+		res.Runs = append(res.Runs, run)
+	}
+	return res, nil
+}
+
+// maxRuns returns how many runs of toDraw cards a deck of n cards allows.
+// With a complete board there is nothing to deal and only one run.
+func maxRuns(n, toDraw int) int {
+	if toDraw == 0 {
+		return 1
+	}
+	return n / toDraw
+}
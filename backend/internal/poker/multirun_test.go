@@ -0,0 +1,70 @@
+package poker
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRunItMultiple(t *testing.T) {
+	hero, villain := mustCards(t, "Ah", "Ad"), mustCards(t, "Kh", "Kd")
+	res, err := RunItMultiple(hero, villain, nil, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Runs) != 3 || res.Seed != 1 {
+		t.Fatalf("got %d runs with seed %d", len(res.Runs), res.Seed)
+	}
+	if math.Abs(res.HeroShare+res.VillainShare-1) > 1e-9 {
+		t.Errorf("shares %v + %v != 1", res.HeroShare, res.VillainShare)
+	}
+	var dealt []Card
+	for _, run := range res.Runs {
+		if len(run.Board) != 5 {
+			t.Errorf("board %v is not complete", run.Board)
+		}
+		dealt = append(dealt, run.Board...)
+	}
+	if HasDuplicates(append(append(dealt, hero...), villain...)) {
+		t.Errorf("a card was dealt twice: %v", dealt)
+	}
+
+	again, _ := RunItMultiple(hero, villain, nil, 3, 1)
+	if !reflect.DeepEqual(res, again) {
+		t.Error("same seed dealt different runs")
+	}
+}
+
+func TestRunItMultipleCompleteBoard(t *testing.T) {
+	res, err := RunItMultiple(mustCards(t, "Ah", "Kh"), mustCards(t, "Ad", "Kd"), mustCards(t, "Qh", "Jh", "Th", "2c", "3d"), 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.HeroShare != 1 || res.Runs[0].Result != 1 {
+		t.Errorf("royal flush: %+v", res)
+	}
+}
+
+func TestRunItMultipleErrors(t *testing.T) {
+	hero, villain := mustCards(t, "Ah", "Ad"), mustCards(t, "Kh", "Kd")
+	flop := mustCards(t, "2c", "7d", "9h")
+	tests := []struct {
+		name                     string
+		hero, villain, community []Card
+		runs                     int
+	}{
+		{"no runs", hero, villain, flop, 0},
+		{"deck too small", hero, villain, flop, 23},
+		{"complete board runs twice", hero, villain, append(flop, mustCards(t, "Js", "4c")...), 2},
+		{"one hole card", hero[:1], villain, flop, 1},
+		{"two-card board", hero, villain, flop[:2], 1},
+	}
+	for _, tt := range tests {
+		if _, err := RunItMultiple(tt.hero, tt.villain, tt.community, tt.runs, 1); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+	if _, err := RunItMultiple(hero, villain, flop, 22, 1); err != nil {
+		t.Errorf("22 runs on the flop: %v", err)
+	}
+}
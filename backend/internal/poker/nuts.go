@@ -0,0 +1,157 @@
+package poker
+
+// MinBeatingHand returns the weakest two-card holding that beats oppHole on
+// a complete 5-card board, along with its hand value. ok is false when no
+// holding beats the opponent (they hold the nuts). Among equally weak
+// holdings the first in deck order is returned.
+func MinBeatingHand(board, oppHole []Card) (hole [2]Card, hv HandValue, ok bool) {
+	if len(board) != 5 || len(oppHole) != 2 {
+		panic("MinBeatingHand requires a 5-card board and 2 opponent hole cards")
+	}
+
+	oppBest := Holdem.BestHand(oppHole, board)
+	for _, cand := range RemainingHoldings(append(append([]Card{}, board...), oppHole...)) {
+		v := Holdem.BestHand(cand[:], board)
+		if CompareHandValues(v, oppBest) <= 0 {
+			continue
+		}
+		if !ok || CompareHandValues(v, hv) < 0 {
+			hole, hv, ok = cand, v, true
+		}
+	}
+	return hole, hv, ok
+}
+
+// NutHand returns the strongest two-card holding on a complete 5-card board
+// and its hand value. Among equally strong holdings the first in deck order
+// is returned.
+func NutHand(board []Card) (hole [2]Card, hv HandValue) {
+	if len(board) != 5 {
+		panic("NutHand requires a 5-card board")
+	}
+
+	first := true
+	for _, cand := range RemainingHoldings(board) {
+		v := Holdem.BestHand(cand[:], board)
+		if first || CompareHandValues(v, hv) > 0 {
+			hole, hv, first = cand, v, false
+		}
+	}
+	return hole, hv
+}
+
+// NutGap returns how many distinct hand values an opponent can make on the
+// board that beat hero's best hand, together with the best hand value still
+// possible for anyone at the table. Opponent holdings never use hero's hole
+// cards, so a gap of zero means hero holds the effective nuts.
+func NutGap(hole, board []Card) (gap int, nuts HandValue) {
+	if len(hole) != 2 || len(board) != 5 {
+		panic("NutGap requires 2 hole cards and a 5-card board")
+	}
+
+	heroBest := Holdem.BestHand(hole, board)
+	nuts = heroBest
+	better := make(map[string]bool)
+	for _, cand := range RemainingHoldings(append(append([]Card{}, board...), hole...)) {
+		v := Holdem.BestHand(cand[:], board)
+		if CompareHandValues(v, heroBest) > 0 {
+			better[v.key()] = true
+		}
+		if CompareHandValues(v, nuts) > 0 {
+			nuts = v
+		}
+	}
+	return len(better), nuts
+}
+
+// IsNuts reports whether hero's two cards make the best hand any holding
+// can make right now on a 3-, 4- or 5-card board, ties included. Unlike
+// NutGap's effective nuts, holdings that would need hero's own cards count
+// too: with AK on a QJT board hero holds the nuts, KK does not.
+func IsNuts(hole, community []Card) bool {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	if len(community) < 3 || len(community) > 5 {
+		panic("community must be 3, 4, or 5 cards")
+	}
+
+	hero := EvaluateBestHand(append(append([]Card{}, hole...), community...))
+	for _, cand := range RemainingHoldings(community) {
+		if CompareHandValues(EvaluateBestHand(append(cand[:], community...)), hero) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// EquityVsNuts returns hero's range equity (0-1, ties count half) against
+// the nut range: every holding that makes the best hand currently possible
+// on a 3-, 4- or 5-card board (on the river, the NutHand value). On the flop
+// and turn every runout is enumerated, so hero can still outdraw the nuts.
+// Each pair of a hero combo and a nut combo sharing no card is weighted
+// equally; hero combos that collide with the board are skipped. It returns
+// 0 if no pair is possible.
+func EquityVsNuts(heroRange [][2]Card, board []Card) float64 {
+	if len(board) < 3 || len(board) > 5 {
+		panic("board must be 3, 4, or 5 cards")
+	}
+
+	var nuts HandValue
+	var nutRange [][2]Card
+	for _, cand := range RemainingHoldings(board) {
+		v := EvaluateBestHand(append(cand[:], board...))
+		switch cmp := CompareHandValues(v, nuts); {
+		case len(nutRange) == 0 || cmp > 0:
+			nuts, nutRange = v, [][2]Card{cand}
+		case cmp == 0:
+			nutRange = append(nutRange, cand)
+		}
+	}
+
+	var used [52]bool
+	for _, c := range board {
+		used[c.index()] = true
+	}
+	var equity float64
+	pairs := 0
+	for _, hero := range heroRange {
+		if used[hero[0].index()] || used[hero[1].index()] || hero[0].index() == hero[1].index() {
+			continue
+		}
+		for _, nut := range nutRange {
+			if HasDuplicates([]Card{hero[0], hero[1], nut[0], nut[1]}) {
+				continue
+			}
+			var won, tied, runouts int
+			forEachRunout([]Card{hero[0], hero[1], nut[0], nut[1]}, board, func(full []Card) {
+				switch cmp := CompareHandValues(Holdem.BestHand(hero[:], full), Holdem.BestHand(nut[:], full)); {
+				case cmp > 0:
+					won++
+				case cmp == 0:
+					tied++
+				}
+				runouts++
+			})
+			equity += (float64(won) + float64(tied)/2) / float64(runouts)
+			pairs++
+		}
+	}
+	if pairs == 0 {
+		return 0
+	}
+	return equity / float64(pairs)
+}
+
+// RemainingHoldings returns every unordered two-card holding that can be
+// made from the cards not in known, in deck order.
+func RemainingHoldings(known []Card) [][2]Card {
+	deck := remainingDeck(known)
+	out := make([][2]Card, 0, len(deck)*(len(deck)-1)/2)
+	for i := 0; i < len(deck); i++ {
+		for j := i + 1; j < len(deck); j++ {
+			out = append(out, [2]Card{deck[i], deck[j]})
+		}
+	}
+	return out
+}
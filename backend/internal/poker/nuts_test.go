@@ -0,0 +1,93 @@
+package poker
+
+import "testing"
+
+func TestMinBeatingHand(t *testing.T) {
+	board := mustCards(t, "As", "Kd", "7c", "4h", "2s")
+
+	// The weakest holding that beats pocket threes pairs the four.
+	_, hv, ok := MinBeatingHand(board, mustCards(t, "3c", "3d"))
+	if want := (HandValue{OnePair, []Rank{Four, Ace, King, Seven}}); !ok || !sameHandValue(hv, want) {
+		t.Errorf("MinBeatingHand = %v, %v, want %v", hv, ok, want)
+	}
+
+	if _, hv, ok := MinBeatingHand(mustCards(t, "Ah", "Kh", "Qh", "Jh", "2c"), mustCards(t, "Th", "3c")); ok {
+		t.Errorf("royal flush is beaten by %v", hv)
+	}
+}
+
+func TestNutHandAndNutGap(t *testing.T) {
+	tests := []struct {
+		name  string
+		board []string
+		hole  []string
+		nuts  HandValue
+		gap   int
+	}{
+		{"hero has the nut straight", []string{"2c", "7d", "9h", "Js", "4c"}, []string{"8c", "Td"}, HandValue{Straight, []Rank{Jack}}, 0},
+		{"set behind the straight", []string{"2c", "7d", "9h", "Js", "4c"}, []string{"Jh", "Jd"}, HandValue{Straight, []Rank{Jack}}, 1},
+		{"royal flush board", []string{"Ah", "Kh", "Qh", "Jh", "Th"}, []string{"2c", "3d"}, HandValue{StraightFlush, []Rank{Ace}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := mustCards(t, tt.board...)
+			if _, hv := NutHand(board); !sameHandValue(hv, tt.nuts) {
+				t.Errorf("NutHand = %v, want %v", hv, tt.nuts)
+			}
+			gap, nuts := NutGap(mustCards(t, tt.hole...), board)
+			if gap != tt.gap || !sameHandValue(nuts, tt.nuts) {
+				t.Errorf("NutGap = %d, %v, want %d, %v", gap, nuts, tt.gap, tt.nuts)
+			}
+		})
+	}
+}
+
+func TestIsNuts(t *testing.T) {
+	tests := []struct {
+		hole, community []string
+		want            bool
+	}{
+		{[]string{"Ah", "Kc"}, []string{"Qd", "Jh", "Ts"}, true},
+		{[]string{"Kh", "Kc"}, []string{"Qd", "Jh", "Ts"}, false},
+		{[]string{"Ah", "Kc"}, []string{"Qd", "Jh", "Ts", "9d"}, true},
+		{[]string{"2c", "3d"}, []string{"Ah", "Kh", "Qh", "Jh", "Th"}, true},
+		{[]string{"Ah", "Ad"}, []string{"2c", "7d", "9h", "Js", "4c"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsNuts(mustCards(t, tt.hole...), mustCards(t, tt.community...)); got != tt.want {
+			t.Errorf("IsNuts(%v, %v) = %v, want %v", tt.hole, tt.community, got, tt.want)
+		}
+	}
+}
+
+func TestEquityVsNuts(t *testing.T) {
+	board := mustCards(t, "2c", "7d", "9h", "Js", "4c")
+	combo := func(a, b string) [2]Card {
+		c := mustCards(t, a, b)
+		return [2]Card{c[0], c[1]}
+	}
+	tests := []struct {
+		name string
+		hero [][2]Card
+		want float64
+	}{
+		{"hero holds a nut combo", [][2]Card{combo("8c", "Td")}, 0.5},
+		{"hero is drawing dead", [][2]Card{combo("Ah", "Ad")}, 0},
+		{"combos on the board are skipped", [][2]Card{combo("2c", "Td")}, 0},
+		{"empty range", nil, 0},
+	}
+	for _, tt := range tests {
+		if got := EquityVsNuts(tt.hero, board); got != tt.want {
+			t.Errorf("%s: EquityVsNuts = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRemainingHoldings(t *testing.T) {
+	if got := len(RemainingHoldings(mustCards(t, "2c", "7d", "9h", "Js", "4c"))); got != 1081 {
+		t.Errorf("len(RemainingHoldings) = %d, want 1081", got)
+	}
+	if got := len(RemainingHoldings(nil)); got != 1326 {
+		t.Errorf("len(RemainingHoldings(nil)) = %d, want 1326", got)
+	}
+}
@@ -0,0 +1,205 @@
+package poker
+
+// Outs returns, in deck order, the unseen cards that would improve hero's
+// hand category on the next card. community must hold 3 or 4 cards.
+func Outs(hole, community []Card) []Card {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	if len(community) != 3 && len(community) != 4 {
+		panic("community must be 3 or 4 cards")
+	}
+
+	current := EvaluateBestHand(append(append([]Card{}, hole...), community...)).Category
+	var outs []Card
+	for _, c := range remainingDeck(hole, community) {
+		next := append(append([]Card{c}, hole...), community...)
+		if EvaluateBestHand(next).Category > current {
+			outs = append(outs, c)
+		}
+	}
+	return outs
+}
+
+// CleanOuts splits hero's Outs into clean outs and dirty outs, which also
+// improve some villain combo into a hand that beats hero (e.g. a flush card
+// that pairs the board and gives a set a full house). Villain combos the
+// out does not improve are ignored: an out is not dirty just because a
+// villain was already ahead. A nil villainRange means any two unseen cards.
+// Villain combos that share a card with hero, the board or the out are
+// ignored for that out.
+func CleanOuts(hole, community []Card, villainRange [][2]Card) (clean, dirty []Card) {
+	outs := Outs(hole, community)
+	if villainRange == nil {
+		villainRange = RemainingHoldings(append(append([]Card{}, hole...), community...))
+	}
+
+	var used [52]bool
+	for _, c := range hole {
+		used[c.index()] = true
+	}
+	for _, c := range community {
+		used[c.index()] = true
+	}
+	before := make([]HandValue, len(villainRange))
+	for i, combo := range villainRange {
+		if !used[combo[0].index()] && !used[combo[1].index()] && combo[0].index() != combo[1].index() {
+			before[i] = EvaluateBestHand(append(combo[:], community...))
+		}
+	}
+
+	for _, out := range outs {
+		board := append(append([]Card{}, community...), out)
+		heroBest := EvaluateBestHand(append(append([]Card{}, hole...), board...))
+		beaten := false
+		for i, combo := range villainRange {
+			if used[combo[0].index()] || used[combo[1].index()] || combo[0].index() == combo[1].index() || combo[0].index() == out.index() || combo[1].index() == out.index() {
+				continue
+			}
+			after := EvaluateBestHand(append(combo[:], board...))
+			if CompareHandValues(after, before[i]) > 0 && CompareHandValues(after, heroBest) > 0 {
+				beaten = true
+				break
+			}
+		}
+		if beaten {
+			dirty = append(dirty, out)
+		} else {
+			clean = append(clean, out)
+		}
+	}
+	return clean, dirty
+}
+
+// ImprovementOdds returns the exact probability (0-1) that hero finishes
+// strictly ahead of villain's known hand, enumerating every runout of a
+// 3- or 4-card board. It is the exact counterpart of rule-of-four style
+// estimates from counting outs; when hero is already ahead it includes the
+// runouts where hero stays ahead.
+func ImprovementOdds(heroHole, villainHole, community []Card) float64 {
+	ahead, _ := improvementOdds(heroHole, villainHole, community, 5-len(community))
+	return ahead
+}
+
+// improvementOdds is ImprovementOdds dealing only the next toCome board
+// cards, and also returns the probability that hero ends up tied.
+func improvementOdds(heroHole, villainHole, community []Card, toCome int) (ahead, tied float64) {
+	if len(heroHole) != 2 || len(villainHole) != 2 {
+		panic("hole cards must have length 2")
+	}
+	if len(community) != 3 && len(community) != 4 {
+		panic("community must be 3 or 4 cards")
+	}
+
+	deck := remainingDeck(heroHole, villainHole, community)
+	board := append(append(make([]Card, 0, 5), community...), make([]Card, toCome)...)
+	var won, split, total int
+	var rec func(pos, start int)
+	rec = func(pos, start int) {
+		if pos == len(board) {
+			total++
+			switch cmp := CompareHandValues(EvaluateBestHand(append(board, heroHole...)), EvaluateBestHand(append(board, villainHole...))); {
+			case cmp > 0:
+				won++
+			case cmp == 0:
+				split++
+			}
+			return
+		}
+		for i := start; i < len(deck); i++ {
+			board[pos] = deck[i]
+			rec(pos+1, i+1)
+		}
+	}
+	rec(len(community), 0)
+	return float64(won) / float64(total), float64(split) / float64(total)
+}
+
+// CallingEVNextCard returns the expected chip gain of calling toCall to see
+// exactly one more card against villain's known hand, with no further
+// betting: hero wins pot (which includes villain's bet) if ahead once the
+// next card is dealt, splits the pot with both bets on a tie, and otherwise
+// loses the call. The odds are ImprovementOdds over the next card only, so
+// outs that only get there on the river count for nothing on the flop.
+func CallingEVNextCard(hole, villainHole, community []Card, pot, toCall float64) float64 {
+	ahead, tied := improvementOdds(hole, villainHole, community, 1)
+	behind := 1 - ahead - tied
+	return ahead*pot + tied*((pot+toCall)/2-toCall) - behind*toCall
+}
+
+// CardImpact classifies every unseen card, as the next board card on a 3-
+// or 4-card board, by how it moves the standing between hero and villain's
+// known hand: helps if hero's position improves (from behind to tied or
+// ahead, or from tied to ahead), hurts if it worsens, and neutral if the
+// leader stays the same. Each list is in deck order.
+func CardImpact(heroHole, villainHole, community []Card) (helps, hurts, neutral []Card) {
+	if len(heroHole) != 2 || len(villainHole) != 2 {
+		panic("hole cards must have length 2")
+	}
+	if len(community) != 3 && len(community) != 4 {
+		panic("community must be 3 or 4 cards")
+	}
+
+	standing := func(board []Card) int {
+		hero := EvaluateBestHand(append(append([]Card{}, heroHole...), board...))
+		villain := EvaluateBestHand(append(append([]Card{}, villainHole...), board...))
+		switch cmp := CompareHandValues(hero, villain); {
+		case cmp > 0:
+			return 1
+		case cmp < 0:
+			return -1
+		default:
+			return 0
+		}
+	}
+
+	now := standing(community)
+	for _, c := range remainingDeck(heroHole, villainHole, community) {
+		switch next := standing(append(append([]Card{}, community...), c)); {
+		case next > now:
+			helps = append(helps, c)
+		case next < now:
+			hurts = append(hurts, c)
+		default:
+			neutral = append(neutral, c)
+		}
+	}
+	return helps, hurts, neutral
+}
+
+// IsFreeroll reports whether one player freerolls the other on a 3- or
+// 4-card board: the two hands tie right now, and over every runout one of
+// them never loses but sometimes wins. who is 1 when hero freerolls, -1
+// when villain does and 0 when neither does.
+func IsFreeroll(hero, villain, community []Card) (bool, int) {
+	if len(hero) != 2 || len(villain) != 2 {
+		panic("hole cards must have length 2")
+	}
+	if len(community) != 3 && len(community) != 4 {
+		panic("community must be 3 or 4 cards")
+	}
+
+	heroNow := EvaluateBestHand(append(append([]Card{}, hero...), community...))
+	villainNow := EvaluateBestHand(append(append([]Card{}, villain...), community...))
+	if CompareHandValues(heroNow, villainNow) != 0 {
+		return false, 0
+	}
+
+	heroWins, villainWins := 0, 0
+	forEachRunout(append(append([]Card{}, hero...), villain...), community, func(board []Card) {
+		switch cmp := CompareHandValues(Holdem.BestHand(hero, board), Holdem.BestHand(villain, board)); {
+		case cmp > 0:
+			heroWins++
+		case cmp < 0:
+			villainWins++
+		}
+	})
+	switch {
+	case heroWins > 0 && villainWins == 0:
+		return true, 1
+	case villainWins > 0 && heroWins == 0:
+		return true, -1
+	default:
+		return false, 0
+	}
+}
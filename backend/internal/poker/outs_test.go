@@ -0,0 +1,139 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOuts(t *testing.T) {
+	tests := []struct {
+		name            string
+		hole, community []string
+		want            int
+	}{
+		// 9 hearts, 6 aces and kings, and 8 cards pairing the board.
+		{"flush draw and overcards", []string{"Ah", "Kh"}, []string{"7h", "2h", "9c"}, 23},
+		{"turn flush draw", []string{"Ah", "Kh"}, []string{"7h", "2h", "9c", "3s"}, 25},
+		{"quads cannot improve", []string{"Ah", "Ad"}, []string{"Ac", "As", "Kc", "Kd"}, 0},
+	}
+	for _, tt := range tests {
+		if got := Outs(mustCards(t, tt.hole...), mustCards(t, tt.community...)); len(got) != tt.want {
+			t.Errorf("%s: Outs = %v, want %d cards", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCleanOuts(t *testing.T) {
+	hole := mustCards(t, "Ah", "Kh")
+	community := mustCards(t, "7h", "7c", "2h")
+
+	// Against pocket nines the 9h fills villain up and the last two sevens
+	// give villain a full house over hero's trips; the other outs either
+	// leave villain unimproved or still behind.
+	clean, dirty := CleanOuts(hole, community, [][2]Card{{mustCards(t, "9s")[0], mustCards(t, "9d")[0]}})
+	if got := CanonicalKey(dirty); got != CanonicalKey(mustCards(t, "9h", "7d", "7s")) {
+		t.Errorf("dirty = %s", got)
+	}
+	if len(clean)+len(dirty) != len(Outs(hole, community)) {
+		t.Errorf("clean %d + dirty %d != %d outs", len(clean), len(dirty), len(Outs(hole, community)))
+	}
+
+	// A villain already holding a full house is ahead of every flush, but
+	// an out that leaves the full house unchanged is not dirty.
+	_, dirty = CleanOuts(hole, community, [][2]Card{{mustCards(t, "2c")[0], mustCards(t, "2d")[0]}})
+	for _, c := range dirty {
+		if c.Suit == Hearts {
+			t.Errorf("flush out %v is dirty against a made full house", c)
+		}
+	}
+
+	clean, dirty = CleanOuts(hole, community, nil)
+	if len(clean)+len(dirty) != len(Outs(hole, community)) || len(dirty) == 0 {
+		t.Errorf("any two cards: clean %v, dirty %v", clean, dirty)
+	}
+}
+
+func TestImprovementOdds(t *testing.T) {
+	hero := mustCards(t, "Ah", "Kh")
+	villain := mustCards(t, "Qs", "Qd")
+	turn := mustCards(t, "7h", "2h", "9c", "3s")
+
+	// 9 hearts and 6 aces and kings of 44 river cards; the rule of two
+	// estimates 30%.
+	got := ImprovementOdds(hero, villain, turn)
+	if want := 15.0 / 44.0; math.Abs(got-want) > 1e-12 {
+		t.Errorf("turn: ImprovementOdds = %v, want %v", got, want)
+	}
+	if ruleOfTwo := 15 * 0.02; math.Abs(got-ruleOfTwo) > 0.05 {
+		t.Errorf("turn: %v is far from the rule-of-two estimate %v", got, ruleOfTwo)
+	}
+
+	flop := turn[:3]
+	got = ImprovementOdds(hero, villain, flop)
+	if ruleOfFour := 15 * 0.04; got <= 15.0/44.0 || math.Abs(got-ruleOfFour) > 0.06 {
+		t.Errorf("flop: ImprovementOdds = %v, rule of four %v", got, ruleOfFour)
+	}
+	if got := ImprovementOdds(villain, hero, flop); got <= 0.4 {
+		t.Errorf("flop: villain ahead only %v", got)
+	}
+}
+
+func TestCallingEVNextCard(t *testing.T) {
+	hero := mustCards(t, "Ah", "Kh")
+	villain := mustCards(t, "Qs", "Qd")
+	tests := []struct {
+		name        string
+		community   []string
+		pot, toCall float64
+		want        float64
+	}{
+		{"turn, correct odds", []string{"7h", "2h", "9c", "3s"}, 100, 50, (15*100 - 29*50) / 44.0},
+		{"turn, incorrect odds", []string{"7h", "2h", "9c", "3s"}, 100, 100, (15*100 - 29*100) / 44.0},
+		// Only the next card counts: 15 of 45 turn cards exactly break even
+		// at 2:1.
+		{"flop, break even", []string{"7h", "2h", "9c"}, 100, 50, 0},
+	}
+	for _, tt := range tests {
+		got := CallingEVNextCard(hero, villain, mustCards(t, tt.community...), tt.pot, tt.toCall)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: CallingEVNextCard = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Chopped pots return hero's call.
+	tie := CallingEVNextCard(mustCards(t, "Ah", "Kd"), mustCards(t, "Ac", "Ks"), mustCards(t, "2h", "7c", "9d", "Js"), 100, 50)
+	if math.Abs(tie-25) > 1e-9 {
+		t.Errorf("chop: CallingEVNextCard = %v, want 25", tie)
+	}
+}
+
+func TestCardImpact(t *testing.T) {
+	helps, hurts, neutral := CardImpact(mustCards(t, "Ah", "Kh"), mustCards(t, "Qs", "Qd"), mustCards(t, "7h", "2h", "9c", "3s"))
+	if len(helps) != 15 || len(hurts) != 0 || len(neutral) != 29 {
+		t.Errorf("helps %d, hurts %d, neutral %d; want 15, 0, 29", len(helps), len(hurts), len(neutral))
+	}
+	helps, hurts, _ = CardImpact(mustCards(t, "Qs", "Qd"), mustCards(t, "Ah", "Kh"), mustCards(t, "7h", "2h", "9c", "3s"))
+	if len(helps) != 0 || len(hurts) != 15 {
+		t.Errorf("swapped: helps %d, hurts %d; want 0, 15", len(helps), len(hurts))
+	}
+}
+
+func TestIsFreeroll(t *testing.T) {
+	tests := []struct {
+		name                     string
+		hero, villain, community []string
+		ok                       bool
+		who                      int
+	}{
+		{"hero freerolls", []string{"Ah", "Kh"}, []string{"Ad", "Kc"}, []string{"Qh", "Jh", "2c"}, true, 1},
+		{"villain freerolls", []string{"Ad", "Kc"}, []string{"Ah", "Kh"}, []string{"Qh", "Jh", "2c"}, true, -1},
+		{"always a chop", []string{"Ah", "Kd"}, []string{"Ac", "Ks"}, []string{"2h", "7c", "9d"}, false, 0},
+		{"not tied now", []string{"Ah", "Ad"}, []string{"Kc", "Ks"}, []string{"2h", "7c", "9d"}, false, 0},
+	}
+	for _, tt := range tests {
+		ok, who := IsFreeroll(mustCards(t, tt.hero...), mustCards(t, tt.villain...), mustCards(t, tt.community...))
+		if ok != tt.ok || who != tt.who {
+			t.Errorf("%s: IsFreeroll = %v, %d, want %v, %d", tt.name, ok, who, tt.ok, tt.who)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package poker
+
+import "sort"
+
+// HandPercentile returns the fraction (0-1) of all C(52,5) five-card hands
+// that are no stronger than hv: 1 for a royal flush, about 0.0004 for the
+// weakest 7-high. hv should come from an evaluator in this package. The
+// error is Init's, if the lookup tables could not be built.
+func HandPercentile(hv HandValue) (float64, error) {
+	if err := Init(); err != nil {
+		return 0, err
+	}
+	i := sort.SearchInts(handScores, hv.Score()+1) - 1
+	if i < 0 {
+		return 0, nil
+	}
+	return scoreAtOrBelow[i], nil
+}
+
+// buildPercentileTable evaluates every 5-card hand once and returns the
+// distinct scores, ascending, with their cumulative share of all hands.
+func buildPercentileTable() ([]int, []float64) {
+	deck := FullDeck()
+	counts := make(map[int]int)
+	total := 0
+	hand := make([]Card, 5)
+	n := len(deck)
+	// Nested loops rather than Combinations, which would hold all 2.6M
+	// index slices in memory at once.
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			for c := b + 1; c < n; c++ {
+				for d := c + 1; d < n; d++ {
+					for e := d + 1; e < n; e++ {
+						hand[0], hand[1], hand[2], hand[3], hand[4] = deck[a], deck[b], deck[c], deck[d], deck[e]
+						counts[evaluate5(hand).Score()]++
+						total++
+					}
+				}
+			}
+		}
+	}
+
+	scores := make([]int, 0, len(counts))
+	for s := range counts {
+		scores = append(scores, s)
+	}
+	sort.Ints(scores)
+
+	cum := make([]float64, len(scores))
+	seen := 0
+	for i, s := range scores {
+		seen += counts[s]
+		cum[i] = float64(seen) / float64(total)
+	}
+	return scores, cum
+}
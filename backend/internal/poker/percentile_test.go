@@ -0,0 +1,33 @@
+package poker
+
+import "testing"
+
+func TestHandPercentile(t *testing.T) {
+	royal := EvaluateBestHand(mustCards(t, "Ah", "Kh", "Qh", "Jh", "Th"))
+	if got := percentile(t, royal); got != 1 {
+		t.Errorf("royal flush percentile = %v, want 1", got)
+	}
+	worst := EvaluateBestHand(mustCards(t, "7h", "5d", "4c", "3s", "2h"))
+	if got := percentile(t, worst); got <= 0 || got > 0.001 {
+		t.Errorf("7-high percentile = %v", got)
+	}
+	if got := percentile(t, HandValue{HighCard, []Rank{Two}}); got != 0 {
+		t.Errorf("below every hand = %v, want 0", got)
+	}
+
+	// Percentiles rise with hand strength.
+	pair := percentile(t, EvaluateBestHand(mustCards(t, "2h", "2d", "4c", "5s", "7h")))
+	trips := percentile(t, EvaluateBestHand(mustCards(t, "2h", "2d", "2c", "5s", "7h")))
+	if !(percentile(t, worst) < pair && pair < trips && trips < 1) {
+		t.Errorf("percentiles out of order: %v, %v, %v", percentile(t, worst), pair, trips)
+	}
+}
+
+func percentile(t *testing.T, hv HandValue) float64 {
+	t.Helper()
+	pct, err := HandPercentile(hv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pct
+}
@@ -0,0 +1,127 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Bot actions returned by BotAction. There is no betting in PlayHand: fold
+// leaves the hand, while check and call both stay in, call marking a bot
+// that is happy to put chips in.
+const (
+	ActionFold  = "fold"
+	ActionCheck = "check"
+	ActionCall  = "call"
+)
+
+// Equity cut-offs for BotAction.
+const (
+	botFoldEquity = 0.20
+	botCallEquity = 0.50
+)
+
+// botTrials is the simulation size behind every bot decision.
+const botTrials = 2000
+
+// BotAction is the bots' decision policy: given the bot's equity (0-1)
+// against the players still in the hand, it folds below 20%, calls from
+// 50% and checks in between.
+func BotAction(equity float64) string {
+	switch {
+	case equity < botFoldEquity:
+		return ActionFold
+	case equity >= botCallEquity:
+		return ActionCall
+	default:
+		return ActionCheck
+	}
+}
+
+// BotStep is one decision in a PlayHand action log.
+type BotStep struct {
+	Street string  // "preflop", "flop", "turn" or "river"
+	Seat   int     // 0-based
+	Equity float64 // the bot's simulated equity vs the live field (0-1)
+	Action string  // ActionFold, ActionCheck or ActionCall
+}
+
+// PlayedHand is the outcome of PlayHand.
+type PlayedHand struct {
+	Holes   [][]Card // every seat's hole cards, including folded seats
+	Board   []Card   // the community cards dealt before the hand ended
+	Actions []BotStep
+	Folded  []int   // seats that folded, in the order they folded
+	Winners []int   // seats that won (split) the hand, ascending
+	Ranking [][]int // showdown tie groups, best first; nil if uncontested
+	Seed    int64
+}
+
+// PlayHand deals a Hold'em hand to players bots and plays it out. On every
+// street each live bot, in seat order, simulates its equity against the
+// other live players' unknown cards and acts by BotAction. A bot that is
+// the last one left wins without acting further; otherwise the hand goes
+// to showdown on the river. The same seed (zero picks one from the clock)
+// replays the same hand.
+func PlayHand(players int, seed int64) PlayedHand {
+	if players < 2 || players > Holdem.MaxOpponents()+1 {
+		panic(fmt.Sprintf("players must be between 2 and %d", Holdem.MaxOpponents()+1))
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	deck := FullDeck()
+	rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	hand := PlayedHand{Holes: make([][]Card, players), Seed: seed}
+	for i := range hand.Holes {
+		hand.Holes[i] = deck[2*i : 2*i+2]
+	}
+	fullBoard := deck[2*players : 2*players+5]
+
+	live := make([]bool, players)
+	numLive := players
+	for i := range live {
+		live[i] = true
+	}
+
+	for street, boardLen := range []int{0, 3, 4, 5} {
+		hand.Board = fullBoard[:boardLen]
+		for seat := 0; seat < players && numLive > 1; seat++ {
+			if !live[seat] {
+				continue
+			}
+			res := SimulateEquityWithOptions(hand.Holes[seat], hand.Board, numLive-1, botTrials, SimulationOptions{Seed: rng.Int63()})
+			heroWin, _, tie := res.Rates()
+			equity := heroWin + tie/2
+			action := BotAction(equity)
+			hand.Actions = append(hand.Actions, BotStep{Street: streetNames[street], Seat: seat, Equity: equity, Action: action})
+			if action == ActionFold {
+				live[seat] = false
+				numLive--
+				hand.Folded = append(hand.Folded, seat)
+			}
+		}
+		if numLive == 1 {
+			for seat, ok := range live {
+				if ok {
+					hand.Winners = []int{seat}
+				}
+			}
+			return hand
+		}
+	}
+
+	holes := make([][]Card, players)
+	for seat, ok := range live {
+		if ok {
+			holes[seat] = hand.Holes[seat]
+		}
+	}
+	hand.Ranking = RankHands(holes, hand.Board)
+	hand.Winners = hand.Ranking[0]
+	return hand
+}
+
+var streetNames = []string{"preflop", "flop", "turn", "river"}
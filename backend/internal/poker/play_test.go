@@ -0,0 +1,65 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBotAction(t *testing.T) {
+	tests := []struct {
+		equity float64
+		want   string
+	}{
+		{0, ActionFold},
+		{0.19, ActionFold},
+		{0.2, ActionCheck},
+		{0.49, ActionCheck},
+		{0.5, ActionCall},
+		{1, ActionCall},
+	}
+	for _, tt := range tests {
+		if got := BotAction(tt.equity); got != tt.want {
+			t.Errorf("BotAction(%v) = %q, want %q", tt.equity, got, tt.want)
+		}
+	}
+}
+
+func TestPlayHand(t *testing.T) {
+	hand := PlayHand(4, 11)
+	if len(hand.Holes) != 4 || len(hand.Winners) == 0 || hand.Seed != 11 {
+		t.Fatalf("PlayHand = %+v", hand)
+	}
+	var dealt []Card
+	for _, h := range hand.Holes {
+		dealt = append(dealt, h...)
+	}
+	if HasDuplicates(append(dealt, hand.Board...)) {
+		t.Errorf("a card was dealt twice")
+	}
+	for _, w := range hand.Winners {
+		for _, f := range hand.Folded {
+			if w == f {
+				t.Errorf("seat %d folded but won", w)
+			}
+		}
+	}
+	if hand.Ranking == nil && len(hand.Folded) != 3 {
+		t.Errorf("no showdown but only %d seats folded", len(hand.Folded))
+	}
+	if again := PlayHand(4, 11); !reflect.DeepEqual(again, hand) {
+		t.Error("same seed played a different hand")
+	}
+}
+
+func TestPlayHandPanicsOnTableSize(t *testing.T) {
+	for _, n := range []int{1, Holdem.MaxOpponents() + 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("PlayHand(%d) did not panic", n)
+				}
+			}()
+			PlayHand(n, 1)
+		}()
+	}
+}
@@ -0,0 +1,197 @@
+package poker
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Pot is a main or side pot: an amount of chips and the players, by index
+// into the contributions passed to SplitPots, who can win it.
+type Pot struct {
+	Amount   int
+	Eligible []int // ascending
+}
+
+// SplitPots builds the main pot and side pots from each player's total
+// contribution to the hand. Every distinct contribution level closes a pot
+// that only players who put in at least that much can win; the main pot
+// comes first. A final pot with a single eligible player is an uncalled bet
+// that simply goes back to them. Players contributing zero are in no pot.
+func SplitPots(contributions []int) []Pot {
+	var levels []int
+	for _, c := range contributions {
+		if c < 0 {
+			panic("contributions must be >= 0")
+		}
+		if c > 0 {
+			levels = append(levels, c)
+		}
+	}
+	sort.Ints(levels)
+
+	var pots []Pot
+	prev := 0
+	for _, level := range levels {
+		if level == prev {
+			continue
+		}
+		var pot Pot
+		for i, c := range contributions {
+			pot.Amount += min(c, level) - min(c, prev)
+			if c >= level {
+				pot.Eligible = append(pot.Eligible, i)
+			}
+		}
+		pots = append(pots, pot)
+		prev = level
+	}
+	return pots
+}
+
+// PotPayout is a pot together with each player's expected share of it.
+type PotPayout struct {
+	Pot
+	Shares []float64 // expected chips won, indexed like the contributions
+}
+
+// ExpectedPayouts runs out the board and reports, for every pot from
+// SplitPots(contributions), how many chips each player wins on average.
+// Each pot goes to the best hands among its eligible players, split evenly
+// on ties. A player with no hole cards has folded: their chips stay in the
+// pots but they cannot win them. A pot that only folded players are
+// eligible for is added to the previous pot.
+//
+// Boards of 3 or more cards are enumerated exactly; preflop, trials random
+// boards are dealt from seed (zero picks one from the clock).
+func ExpectedPayouts(holes [][]Card, board []Card, contributions []int, trials int, seed int64) []PotPayout {
+	return ExpectedPayoutsWithButton(holes, board, contributions, trials, seed, -1)
+}
+
+// ExpectedPayoutsWithButton is like ExpectedPayouts but splits tied pots
+// into whole chips with AssignOddChips, the button being at index button.
+// A negative button splits tied pots exactly, as ExpectedPayouts does.
+func ExpectedPayoutsWithButton(holes [][]Card, board []Card, contributions []int, trials int, seed int64, button int) []PotPayout {
+	if len(holes) != len(contributions) {
+		panic("holes and contributions must have the same length")
+	}
+	if len(board) != 0 && len(board) != 3 && len(board) != 4 && len(board) != 5 {
+		panic("board must be 0, 3, 4, or 5 cards")
+	}
+
+	var known []Card
+	for _, h := range holes {
+		known = append(known, h...)
+	}
+
+	// Fold pots nobody live can win into the previous pot.
+	var payouts []PotPayout
+	for _, pot := range SplitPots(contributions) {
+		var live []int
+		for _, p := range pot.Eligible {
+			if len(holes[p]) > 0 {
+				live = append(live, p)
+			}
+		}
+		if len(live) == 0 {
+			if len(payouts) == 0 {
+				panic("no live player is eligible for the main pot")
+			}
+			payouts[len(payouts)-1].Amount += pot.Amount
+			continue
+		}
+		pot.Eligible = live
+		payouts = append(payouts, PotPayout{Pot: pot, Shares: make([]float64, len(holes))})
+	}
+
+	runouts := 0
+	award := func(full []Card) {
+		groups := RankHands(holes, full)
+		for _, pp := range payouts {
+			for _, g := range groups {
+				var winners []int
+				for _, p := range g {
+					if containsInt(pp.Eligible, p) {
+						winners = append(winners, p)
+					}
+				}
+				if len(winners) == 0 {
+					continue
+				}
+				if button < 0 {
+					for _, p := range winners {
+						pp.Shares[p] += float64(pp.Amount) / float64(len(winners))
+					}
+				} else {
+					for p, chips := range AssignOddChips(pp.Amount, winners, button) {
+						pp.Shares[p] += float64(chips)
+					}
+				}
+				break
+			}
+		}
+		runouts++
+	}
+
+	if len(board) > 0 {
+		forEachRunout(known, board, award)
+	} else {
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		rng := rand.New(rand.NewSource(seed))
+		deck := remainingDeck(known)
+		tmp := make([]Card, len(deck))
+		for t := 0; t < trials; t++ {
+			shuffleInto(rng, tmp, deck)
+			award(tmp[:5])
+		}
+	}
+
+	for _, pp := range payouts {
+		for p := range pp.Shares {
+			if runouts > 0 {
+				pp.Shares[p] /= float64(runouts)
+			}
+		}
+	}
+	return payouts
+}
+
+// AssignOddChips splits a pot of whole chips among tied winners, given as
+// seat indexes. Each winner gets pot/len(winners) chips; the chips left
+// over go one each to the winners closest to the left of the button,
+// i.e. in ascending seat order starting after buttonPos and wrapping
+// around. It returns each winner's chips.
+func AssignOddChips(pot int, winners []int, buttonPos int) map[int]int {
+	if len(winners) == 0 {
+		panic("AssignOddChips requires at least one winner")
+	}
+	order := append([]int{}, winners...)
+	sort.Slice(order, func(i, j int) bool {
+		// Seats after the button sort before those at or before it.
+		ai, aj := order[i] <= buttonPos, order[j] <= buttonPos
+		if ai != aj {
+			return !ai
+		}
+		return order[i] < order[j]
+	})
+
+	out := make(map[int]int, len(order))
+	for i, seat := range order {
+		out[seat] = pot / len(order)
+		if i < pot%len(order) {
+			out[seat]++
+		}
+	}
+	return out
+}
+
+func containsInt(xs []int, x int) bool {
+	for _, v := range xs {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package poker
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSplitPots(t *testing.T) {
+	tests := []struct {
+		name          string
+		contributions []int
+		want          []Pot
+	}{
+		{"single pot", []int{100, 100}, []Pot{{200, []int{0, 1}}}},
+		{"side pots", []int{100, 50, 100, 0, 200}, []Pot{{200, []int{0, 1, 2, 4}}, {150, []int{0, 2, 4}}, {100, []int{4}}}},
+		{"nobody bet", []int{0, 0}, nil},
+	}
+	for _, tt := range tests {
+		if got := SplitPots(tt.contributions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: SplitPots = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExpectedPayouts(t *testing.T) {
+	board := mustCards(t, "2c", "7d", "9h", "Js", "4c")
+	tests := []struct {
+		name          string
+		holes         [][]string
+		contributions []int
+		amounts       []int
+		shares        [][]float64
+	}{
+		{"short stack loses", [][]string{{"Ah", "Ad"}, {"Kh", "Kd"}, {"8c", "Td"}}, []int{100, 50, 100}, []int{150, 100}, [][]float64{{0, 0, 150}, {0, 0, 100}}},
+		{"folded chips stay in", [][]string{{"Ah", "Ad"}, {"Kh", "Kd"}, nil}, []int{100, 100, 100}, []int{300}, [][]float64{{300, 0, 0}}},
+		{"dead side pot joins the main pot", [][]string{{"Ah", "Ad"}, {"Kh", "Kd"}, nil}, []int{50, 50, 100}, []int{200}, [][]float64{{200, 0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holes := make([][]Card, len(tt.holes))
+			for i, h := range tt.holes {
+				if h != nil {
+					holes[i] = mustCards(t, h...)
+				}
+			}
+			got := ExpectedPayouts(holes, board, tt.contributions, 0, 0)
+			if len(got) != len(tt.amounts) {
+				t.Fatalf("got %d pots, want %d", len(got), len(tt.amounts))
+			}
+			for i, pp := range got {
+				if pp.Amount != tt.amounts[i] || !reflect.DeepEqual(pp.Shares, tt.shares[i]) {
+					t.Errorf("pot %d = %d %v, want %d %v", i, pp.Amount, pp.Shares, tt.amounts[i], tt.shares[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExpectedPayoutsPreflopSharesSumToPot(t *testing.T) {
+	holes := [][]Card{mustCards(t, "Ah", "Ad"), mustCards(t, "Kh", "Kd"), mustCards(t, "7c", "2d")}
+	for _, pp := range ExpectedPayouts(holes, nil, []int{100, 60, 100}, 2000, 1) {
+		sum := 0.0
+		for _, s := range pp.Shares {
+			sum += s
+		}
+		if math.Abs(sum-float64(pp.Amount)) > 1e-9 {
+			t.Errorf("pot %d: shares sum to %v", pp.Amount, sum)
+		}
+	}
+}
+
+func TestExpectedPayoutsWithButtonAssignsOddChip(t *testing.T) {
+	holes := [][]Card{mustCards(t, "Ah", "Kd"), mustCards(t, "Ac", "Ks"), nil}
+	board := mustCards(t, "2h", "7c", "9d", "Js", "3c")
+	got := ExpectedPayoutsWithButton(holes, board, []int{25, 25, 25}, 0, 0, 0)
+	if want := []float64{37, 38, 0}; len(got) != 1 || !reflect.DeepEqual(got[0].Shares, want) {
+		t.Errorf("got %+v, want shares %v", got, want)
+	}
+}
+
+func TestAssignOddChips(t *testing.T) {
+	tests := []struct {
+		pot     int
+		winners []int
+		button  int
+		want    map[int]int
+	}{
+		{100, []int{3, 5}, 5, map[int]int{3: 50, 5: 50}},
+		{10, []int{0, 1, 2}, 1, map[int]int{2: 4, 0: 3, 1: 3}},
+		{11, []int{0, 1, 2}, 1, map[int]int{2: 4, 0: 4, 1: 3}},
+		{7, []int{4, 1}, 2, map[int]int{4: 4, 1: 3}},
+		{7, []int{4, 1}, 5, map[int]int{1: 4, 4: 3}},
+	}
+	for _, tt := range tests {
+		if got := AssignOddChips(tt.pot, tt.winners, tt.button); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("AssignOddChips(%d, %v, %d) = %v, want %v", tt.pot, tt.winners, tt.button, got, tt.want)
+		}
+	}
+}
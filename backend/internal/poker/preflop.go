@@ -0,0 +1,216 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// StartingHand is one of the 169 strategically distinct Hold'em starting
+// hand classes, e.g. "AA", "AKs" or "T9o".
+type StartingHand struct {
+	Name   string
+	High   Rank
+	Low    Rank
+	Suited bool
+	Equity float64 // heads-up equity (%) against a random hand
+}
+
+// StartingHandName returns the class name ("AA", "AKs", "T9o") of a
+// two-card holding.
+func StartingHandName(hole []Card) string {
+	if len(hole) != 2 {
+		panic("StartingHandName requires exactly 2 cards")
+	}
+	hi, lo := hole[0], hole[1]
+	if lo.Rank > hi.Rank {
+		hi, lo = lo, hi
+	}
+	if hi.Rank == lo.Rank {
+		return string([]byte{rankChar(hi.Rank), rankChar(lo.Rank)})
+	}
+	suffix := byte('o')
+	if hi.Suit == lo.Suit {
+		suffix = 's'
+	}
+	return string([]byte{rankChar(hi.Rank), rankChar(lo.Rank), suffix})
+}
+
+// Combos returns every concrete two-card holding in the class: 6 for a
+// pair, 4 for a suited hand and 12 for an offsuit hand.
+func (h StartingHand) Combos() [][2]Card {
+	suits := []Suit{Hearts, Diamonds, Clubs, Spades}
+	var out [][2]Card
+	for i, s1 := range suits {
+		for j, s2 := range suits {
+			switch {
+			case h.High == h.Low && j <= i:
+				continue
+			case h.High != h.Low && h.Suited != (s1 == s2):
+				continue
+			}
+			out = append(out, [2]Card{newCard(s1, h.High), newCard(s2, h.Low)})
+		}
+	}
+	return out
+}
+
+// StartingHands returns all 169 starting hand classes ordered from the
+// strongest to the weakest by heads-up equity against a random hand.
+func StartingHands() []StartingHand {
+	if err := Init(); err != nil {
+		panic(err)
+	}
+	return append([]StartingHand(nil), rankedStartingHands...)
+}
+
+// StartingHandRanking returns the names of all 169 starting hand classes
+// in StartingHands order, strongest first: "AA", "KK", "QQ", ...
+func StartingHandRanking() []string {
+	hands := StartingHands()
+	names := make([]string, len(hands))
+	for i, h := range hands {
+		names[i] = h.Name
+	}
+	return names
+}
+
+// buildStartingHands turns preflopEquity into a ranked StartingHand list.
+func buildStartingHands() ([]StartingHand, error) {
+	out := make([]StartingHand, 0, len(preflopEquity))
+	for name, eq := range preflopEquity {
+		hi, ok1 := parseRank(name[:1])
+		lo, ok2 := parseRank(name[1:2])
+		if !ok1 || !ok2 || lo > hi {
+			return nil, fmt.Errorf("invalid starting hand in preflop table: %s", name)
+		}
+		out = append(out, StartingHand{
+			Name:   name,
+			High:   hi,
+			Low:    lo,
+			Suited: len(name) == 3 && name[2] == 's',
+			Equity: eq,
+		})
+	}
+	if len(out) != 169 {
+		return nil, fmt.Errorf("preflop table has %d starting hands, want 169", len(out))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Equity != out[j].Equity {
+			return out[i].Equity > out[j].Equity
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// TopStartingHands returns the n strongest starting hand classes.
+// n is clamped to [0, 169].
+func TopStartingHands(n int) []StartingHand {
+	all := StartingHands()
+	if n < 0 {
+		n = 0
+	}
+	if n > len(all) {
+		n = len(all)
+	}
+	return all[:n]
+}
+
+// MaxPossibleCategory returns the best hand category hero can make with
+// both hole cards playing, whatever the board: Four of a Kind for a pocket
+// pair, Straight Flush for suited cards that fit in one straight (the ace
+// counting low too), and Full House otherwise, since any two ranks can fill
+// up. Hands using only one hole card, or playing the board, are ignored; on
+// the right board any holding reaches a straight flush that way.
+func MaxPossibleCategory(hole []Card) int {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	a, b := hole[0], hole[1]
+	if a.Rank == b.Rank {
+		return FourOfAKind
+	}
+	if a.Suit == b.Suit && longestStraightWindow(hole) == 2 {
+		return StraightFlush
+	}
+	return FullHouse
+}
+
+// Connectedness returns the number of ranks missing between two hole
+// cards: 0 for connectors such as KQ, 1 for one-gappers such as J9, and so
+// on. The ace counts high or low, whichever is closer, so AK and A2 are
+// both connectors. A pocket pair returns -1.
+func Connectedness(hole []Card) int {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	hi, lo := hole[0].Rank, hole[1].Rank
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+	gap := int(hi-lo) - 1
+	if hi == Ace {
+		// Playing the ace as a one, the wheel connects it to the deuce.
+		gap = min(gap, int(lo-Two))
+	}
+	return gap
+}
+
+// DominationScore returns the fraction (0-1) of villainRange combos that
+// dominate hero's starting hand: they share a rank with it and their other
+// card outranks hero's other card, as AK and AA both dominate AQ. A pocket
+// pair cannot be dominated this way and scores 0. Combos that share a card
+// with hero are skipped; if none remain it returns 0.
+func DominationScore(hole []Card, villainRange [][2]Card) float64 {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+
+	valid, dominated := 0, 0
+	for _, combo := range villainRange {
+		if HasDuplicates([]Card{hole[0], hole[1], combo[0], combo[1]}) {
+			continue
+		}
+		valid++
+		if hole[0].Rank != hole[1].Rank && dominates(combo, hole) {
+			dominated++
+		}
+	}
+	if valid == 0 {
+		return 0
+	}
+	return float64(dominated) / float64(valid)
+}
+
+// dominates reports whether villain shares a rank with an unpaired hero
+// hand and holds a higher card alongside it.
+func dominates(villain [2]Card, hole []Card) bool {
+	for i, h := range hole {
+		kicker := hole[1-i].Rank
+		for j, v := range villain {
+			if v.Rank == h.Rank && villain[1-j].Rank > kicker {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// preflopEquity holds the heads-up all-in equity (%) of every starting hand
+// class against a uniformly random opponent hand. Values were produced
+// offline with SimulateEquity at 40,000 trials per class.
+var preflopEquity = map[string]float64{
+	"AA": 85.1, "AKs": 66.8, "AKo": 65.4, "AQs": 66.4, "AQo": 64.5, "AJs": 65.5, "AJo": 63.5, "ATs": 64.8, "ATo": 62.4, "A9s": 62.6, "A9o": 60.7, "A8s": 62.2, "A8o": 59.6, "A7s": 61.2, "A7o": 58.8, "A6s": 59.7, "A6o": 57.1, "A5s": 60.2, "A5o": 57.5, "A4s": 59.2, "A4o": 57.0, "A3s": 58.6, "A3o": 55.6, "A2s": 57.8, "A2o": 54.9,
+	"KK": 82.3, "KQs": 63.2, "KQo": 61.4, "KJs": 62.8, "KJo": 60.6, "KTs": 62.1, "KTo": 60.0, "K9s": 60.6, "K9o": 58.2, "K8s": 58.4, "K8o": 55.8, "K7s": 57.5, "K7o": 55.3, "K6s": 56.9, "K6o": 54.4, "K5s": 55.4, "K5o": 53.4, "K4s": 54.8, "K4o": 52.1, "K3s": 54.2, "K3o": 51.6, "K2s": 53.6, "K2o": 50.6,
+	"QQ": 79.5, "QJs": 60.6, "QJo": 57.8, "QTs": 59.5, "QTo": 57.5, "Q9s": 57.7, "Q9o": 55.3, "Q8s": 55.9, "Q8o": 53.8, "Q7s": 54.9, "Q7o": 51.7, "Q6s": 54.0, "Q6o": 51.3, "Q5s": 53.1, "Q5o": 50.2, "Q4s": 51.8, "Q4o": 49.5, "Q3s": 51.2, "Q3o": 47.8, "Q2s": 50.5, "Q2o": 47.4,
+	"JJ": 77.2, "JTs": 57.7, "JTo": 55.3, "J9s": 55.7, "J9o": 53.6, "J8s": 53.8, "J8o": 51.0, "J7s": 52.4, "J7o": 50.2, "J6s": 50.8, "J6o": 47.8, "J5s": 49.9, "J5o": 47.1, "J4s": 48.9, "J4o": 46.0, "J3s": 48.1, "J3o": 45.2, "J2s": 47.3, "J2o": 44.3,
+	"TT": 75.2, "T9s": 53.9, "T9o": 51.4, "T8s": 52.5, "T8o": 49.5, "T7s": 50.4, "T7o": 47.9, "T6s": 49.1, "T6o": 46.3, "T5s": 47.1, "T5o": 44.0, "T4s": 46.1, "T4o": 43.3, "T3s": 45.6, "T3o": 42.5, "T2s": 44.8, "T2o": 41.9,
+	"99": 71.9, "98s": 50.9, "98o": 48.3, "97s": 49.3, "97o": 46.4, "96s": 47.5, "96o": 44.5, "95s": 45.9, "95o": 42.5, "94s": 43.9, "94o": 40.4, "93s": 43.7, "93o": 40.6, "92s": 42.7, "92o": 39.3,
+	"88": 69.4, "87s": 47.7, "87o": 45.0, "86s": 46.5, "86o": 43.2, "85s": 44.3, "85o": 41.0, "84s": 42.6, "84o": 39.5, "83s": 40.9, "83o": 37.6, "82s": 39.9, "82o": 37.0,
+	"77": 66.5, "76s": 45.7, "76o": 42.4, "75s": 43.5, "75o": 40.6, "74s": 41.5, "74o": 38.4, "73s": 39.9, "73o": 36.1, "72s": 37.7, "72o": 34.6,
+	"66": 63.3, "65s": 43.3, "65o": 40.0, "64s": 41.4, "64o": 38.5, "63s": 39.8, "63o": 35.9, "62s": 37.7, "62o": 34.0,
+	"55": 60.5, "54s": 41.2, "54o": 38.4, "53s": 39.3, "53o": 35.9, "52s": 37.9, "52o": 34.4,
+	"44": 57.1, "43s": 38.9, "43o": 35.2, "42s": 36.6, "42o": 33.2,
+	"33": 53.5, "32s": 35.9, "32o": 32.9,
+	"22": 50.4,
+}
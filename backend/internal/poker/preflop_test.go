@@ -0,0 +1,130 @@
+package poker
+
+import "testing"
+
+func TestStartingHandName(t *testing.T) {
+	tests := []struct {
+		hole []string
+		want string
+	}{
+		{[]string{"Ah", "Kh"}, "AKs"},
+		{[]string{"Kd", "Ah"}, "AKo"},
+		{[]string{"7c", "7d"}, "77"},
+		{[]string{"9d", "Td"}, "T9s"},
+	}
+	for _, tt := range tests {
+		if got := StartingHandName(mustCards(t, tt.hole...)); got != tt.want {
+			t.Errorf("StartingHandName(%v) = %q, want %q", tt.hole, got, tt.want)
+		}
+	}
+}
+
+func TestStartingHandRanking(t *testing.T) {
+	names := StartingHandRanking()
+	if len(names) != 169 {
+		t.Fatalf("%d starting hands, want 169", len(names))
+	}
+	if names[0] != "AA" || names[1] != "KK" {
+		t.Errorf("ranking starts %v", names[:2])
+	}
+	seen := make(map[string]bool)
+	combos := 0
+	for i, h := range StartingHands() {
+		if seen[h.Name] {
+			t.Errorf("%s listed twice", h.Name)
+		}
+		seen[h.Name] = true
+		if h.Name != names[i] {
+			t.Errorf("StartingHands()[%d] = %s, ranking has %s", i, h.Name, names[i])
+		}
+		if i > 0 && h.Equity > StartingHands()[i-1].Equity {
+			t.Errorf("%s ranked below a weaker hand", h.Name)
+		}
+		for _, c := range h.Combos() {
+			if StartingHandName(c[:]) != h.Name {
+				t.Errorf("%s combo %v is named %s", h.Name, c, StartingHandName(c[:]))
+			}
+		}
+		combos += len(h.Combos())
+	}
+	if combos != totalCombos {
+		t.Errorf("classes cover %d combos, want %d", combos, totalCombos)
+	}
+}
+
+func TestTopStartingHands(t *testing.T) {
+	tests := []struct {
+		n, want int
+	}{
+		{-1, 0}, {0, 0}, {3, 3}, {169, 169}, {500, 169},
+	}
+	for _, tt := range tests {
+		if got := len(TopStartingHands(tt.n)); got != tt.want {
+			t.Errorf("len(TopStartingHands(%d)) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+	if top := TopStartingHands(3); top[0].Name != "AA" || top[1].Name != "KK" || top[2].Name != "QQ" {
+		t.Errorf("TopStartingHands(3) = %v", top)
+	}
+}
+
+func TestMaxPossibleCategory(t *testing.T) {
+	tests := []struct {
+		hole []string
+		want int
+	}{
+		{[]string{"7c", "7d"}, FourOfAKind},
+		{[]string{"Ah", "Kh"}, StraightFlush},
+		{[]string{"Ah", "2h"}, StraightFlush},
+		{[]string{"9h", "5h"}, StraightFlush},
+		{[]string{"Ah", "9h"}, FullHouse},
+		{[]string{"Ah", "Kd"}, FullHouse},
+	}
+	for _, tt := range tests {
+		if got := MaxPossibleCategory(mustCards(t, tt.hole...)); got != tt.want {
+			t.Errorf("MaxPossibleCategory(%v) = %d, want %d", tt.hole, got, tt.want)
+		}
+	}
+}
+
+func TestConnectedness(t *testing.T) {
+	tests := []struct {
+		hole []string
+		want int
+	}{
+		{[]string{"Kh", "Qd"}, 0},
+		{[]string{"9c", "Jh"}, 1},
+		{[]string{"Ah", "Kd"}, 0},
+		{[]string{"Ah", "2d"}, 0},
+		{[]string{"Ah", "5d"}, 3},
+		{[]string{"7c", "2d"}, 4},
+		{[]string{"7c", "7d"}, -1},
+	}
+	for _, tt := range tests {
+		if got := Connectedness(mustCards(t, tt.hole...)); got != tt.want {
+			t.Errorf("Connectedness(%v) = %d, want %d", tt.hole, got, tt.want)
+		}
+	}
+}
+
+func TestDominationScore(t *testing.T) {
+	villain := [][2]Card{
+		{mustCards(t, "Ac")[0], mustCards(t, "Kc")[0]},
+		{mustCards(t, "Ks")[0], mustCards(t, "Kh")[0]},
+		{mustCards(t, "As")[0], mustCards(t, "Jc")[0]},
+		{mustCards(t, "Ah")[0], mustCards(t, "Ad")[0]},
+	}
+	tests := []struct {
+		hole []string
+		want float64
+	}{
+		{[]string{"Ah", "Qd"}, 1.0 / 3},
+		{[]string{"Kd", "Qd"}, 0.5},
+		{[]string{"Qh", "Qd"}, 0},
+	}
+	for _, tt := range tests {
+		if got := DominationScore(mustCards(t, tt.hole...), villain); got != tt.want {
+			t.Errorf("DominationScore(%v) = %v, want %v", tt.hole, got, tt.want)
+		}
+	}
+}
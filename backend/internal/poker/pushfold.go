@@ -0,0 +1,38 @@
+package poker
+
+// pushFoldBlindsBB is the dead money a push-fold shove steals: the small
+// and big blind, in big blinds. Antes are ignored.
+const pushFoldBlindsBB = 1.5
+
+// DefaultCallRangePct is the calling range, in percent of starting hands,
+// assumed for each player behind when none is given.
+const DefaultCallRangePct = 15
+
+// PushFoldEV compares jamming stackBB big blinds with folding, for a hero
+// outside the blinds with playersBehind players still to act. Each of them
+// calls with the top callRangePct percent of hands, and hero's equity when
+// called is simulated heads-up against that range with the given trials
+// and seed (zero picks one from the clock). EVs are chip EV, hero's
+// expected stack in big blinds after the hand; see PushEV for the model.
+func PushFoldEV(hole []Card, stackBB float64, playersBehind int, callRangePct float64, trials int, seed int64) (push, fold float64) {
+	callRange := TopPercentRange(callRangePct)
+	res := SimulateEquityWithOptions(hole, nil, 1, trials, SimulationOptions{VillainRange: callRange, Seed: seed})
+	heroWin, _, tie := res.Rates()
+	callProb := float64(len(callRange)) / totalCombos
+	return PushEV(stackBB, heroWin+tie/2, callProb, playersBehind), stackBB
+}
+
+// PushEV returns hero's expected stack, in big blinds, after jamming
+// stackBB: every player behind calls independently with probability
+// callProb; if all fold hero wins the blinds, and otherwise hero is called
+// by one player covering hero and wins stackBB plus the blinds with
+// probability equity (0-1) or loses the stack. Folding is worth stackBB,
+// so pushing is right when PushEV exceeds it.
+func PushEV(stackBB, equity, callProb float64, playersBehind int) float64 {
+	allFold := 1.0
+	for i := 0; i < playersBehind; i++ {
+		allFold *= 1 - callProb
+	}
+	called := equity * (2*stackBB + pushFoldBlindsBB)
+	return allFold*(stackBB+pushFoldBlindsBB) + (1-allFold)*called
+}
@@ -0,0 +1,32 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPushEV(t *testing.T) {
+	tests := []struct {
+		name                    string
+		stack, equity, callProb float64
+		behind                  int
+		want                    float64
+	}{
+		{"nobody behind", 10, 0.5, 0.3, 0, 11.5},
+		{"always called", 10, 0.5, 1, 1, 10.75},
+		{"always called, no equity", 10, 0, 1, 3, 0},
+		{"one player behind", 10, 0.5, 0.5, 1, 0.5*11.5 + 0.5*10.75},
+	}
+	for _, tt := range tests {
+		if got := PushEV(tt.stack, tt.equity, tt.callProb, tt.behind); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: PushEV = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPushFoldEV(t *testing.T) {
+	push, fold := PushFoldEV(mustCards(t, "Ah", "Ad"), 10, 3, DefaultCallRangePct, 2000, 1)
+	if fold != 10 || push <= fold {
+		t.Errorf("AA at 10bb: push %v, fold %v", push, fold)
+	}
+}
@@ -0,0 +1,410 @@
+package poker
+
+import (
+	"math/rand"
+	"time"
+)
+
+// totalCombos is the number of distinct two-card holdings, C(52,2).
+const totalCombos = 1326
+
+// TopPercentRange returns the concrete two-card combos that make up the top
+// pct percent (0-100) of starting hands, using the StartingHands ranking.
+// Whole classes are added until their combos cover at least pct percent of
+// all 1326 holdings.
+func TopPercentRange(pct float64) [][2]Card {
+	if pct <= 0 {
+		return nil
+	}
+	target := pct / 100.0 * totalCombos
+
+	var out [][2]Card
+	for _, h := range StartingHands() {
+		if float64(len(out)) >= target {
+			break
+		}
+		out = append(out, h.Combos()...)
+	}
+	return out
+}
+
+// ReasonableRangePct is the size, in percent of starting hands, of the
+// default "reasonable" opponent range used by RangeAssumptionEquity.
+const ReasonableRangePct = 25
+
+// RangeAssumptionEquity returns hero's heads-up equity (0-1, ties count
+// half) against any two cards and against TopPercentRange(ReasonableRangePct),
+// showing how much the range assumption moves the estimate. Both runs use the
+// same seed (zero picks one from the clock).
+func RangeAssumptionEquity(hole, community []Card, trials int, seed int64) (random, reasonable float64) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	equity := func(villainRange [][2]Card) float64 {
+		res := SimulateEquityWithOptions(hole, community, 1, trials, SimulationOptions{VillainRange: villainRange, Seed: seed})
+		heroWin, _, tie := res.Rates()
+		return heroWin + tie/2
+	}
+	return equity(nil), equity(TopPercentRange(ReasonableRangePct))
+}
+
+// SimulateEquityVsRange is like SimulateEquity but each opponent's hole
+// cards are drawn uniformly from villainRange instead of from the deck.
+// Each opponent's combo is drawn among those that share no card with hero,
+// the board or earlier opponents.
+//
+// If every combo is blocked for some opponent, that trial is skipped and
+// not counted in TrialsRun.
+func SimulateEquityVsRange(heroHole []Card, community []Card, villainRange [][2]Card, numOpponents, trials int) SimulationResult {
+	if len(villainRange) == 0 {
+		return SimulationResult{Method: MethodMonteCarlo}
+	}
+	return SimulateEquityWithOptions(heroHole, community, numOpponents, trials, SimulationOptions{VillainRange: villainRange})
+}
+
+func rangeWorker(heroHole []Card, community []Card, villainRange comboSampler, numOpponents int) func(*rand.Rand, *SimulationResult, int) {
+	return func(rng *rand.Rand, local *SimulationResult, n int) {
+		for i := 0; i < n; i++ {
+			if heroWin, villainWin, tie, ok := playOutVsRange(rng, heroHole, community, villainRange, numOpponents); ok {
+				local.record(heroWin, villainWin, tie)
+			}
+		}
+	}
+}
+
+func playOutVsRange(rng *rand.Rand, heroHole []Card, community []Card, villainRange comboSampler, numOpponents int) (heroWin, villainWin, tie, ok bool) {
+	var used [52]bool
+	for _, c := range heroHole {
+		used[c.index()] = true
+	}
+	for _, c := range community {
+		used[c.index()] = true
+	}
+
+	oppHoles := make([][2]Card, 0, numOpponents)
+	for opp := 0; opp < numOpponents; opp++ {
+		combo, found := villainRange.draw(rng, &used)
+		if !found {
+			return false, false, false, false
+		}
+		oppHoles = append(oppHoles, combo)
+	}
+
+	// Complete the board from the cards nobody holds.
+	board := append([]Card{}, community...)
+	var rest []Card
+	for _, c := range FullDeck() {
+		if !used[c.index()] {
+			rest = append(rest, c)
+		}
+	}
+	for len(board) < 5 {
+		i := rng.Intn(len(rest))
+		board = append(board, rest[i])
+		rest[i] = rest[len(rest)-1]
+		rest = rest[:len(rest)-1]
+	}
+
+	heroBest := Holdem.BestHand(heroHole, board)
+	villainBetter := false
+	equalCount := 0
+	for _, oh := range oppHoles {
+		cmp := CompareHandValues(Holdem.BestHand(oh[:], board), heroBest)
+		if cmp > 0 {
+			villainBetter = true
+		} else if cmp == 0 {
+			equalCount++
+		}
+	}
+
+	switch {
+	case villainBetter:
+		return false, true, false, true
+	case equalCount > 0:
+		return false, false, true, true
+	default:
+		return true, false, false, true
+	}
+}
+
+// ExactRangeEquity computes hero's exact showdown result on a complete
+// 5-card board against every combo in villainRange, each weighted equally.
+// Combos that share a card with hero or the board are skipped; TrialsRun is
+// the number of combos evaluated.
+func ExactRangeEquity(heroHole, board []Card, villainRange [][2]Card) SimulationResult {
+	if len(heroHole) != 2 || len(board) != 5 {
+		panic("ExactRangeEquity requires 2 hole cards and a 5-card board")
+	}
+
+	var used [52]bool
+	for _, c := range heroHole {
+		used[c.index()] = true
+	}
+	for _, c := range board {
+		used[c.index()] = true
+	}
+
+	res := SimulationResult{Method: MethodExact}
+	heroBest := Holdem.BestHand(heroHole, board)
+	for _, combo := range villainRange {
+		if used[combo[0].index()] || used[combo[1].index()] || combo[0].index() == combo[1].index() {
+			continue
+		}
+		cmp := CompareHandValues(Holdem.BestHand(combo[:], board), heroBest)
+		res.record(cmp < 0, cmp > 0, cmp == 0)
+	}
+	return res
+}
+
+// HandPercentileVsRange returns the fraction (0-1) of villainRange that
+// hero's current made hand beats on a 3-, 4- or 5-card board, counting
+// ties as half. No cards are dealt: on the flop and turn it measures where
+// hero stands now, not hero's equity. Combos that share a card with hero or
+// the board are skipped; if none remain it returns 0.
+func HandPercentileVsRange(hole, community []Card, villainRange [][2]Card) float64 {
+	ahead, tied, behind := CurrentStanding(hole, community, villainRange)
+	n := ahead + tied + behind
+	if n == 0 {
+		return 0
+	}
+	return (float64(ahead) + float64(tied)/2) / float64(n)
+}
+
+// CurrentStanding counts the combos in villainRange that hero's current
+// made hand beats, ties and loses to on a 3-, 4- or 5-card board, without
+// dealing any further cards. Combos that share a card with hero or the
+// board are skipped.
+func CurrentStanding(hole, community []Card, villainRange [][2]Card) (ahead, tied, behind int) {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	if len(community) < 3 || len(community) > 5 {
+		panic("community must be 3, 4, or 5 cards")
+	}
+
+	var used [52]bool
+	for _, c := range hole {
+		used[c.index()] = true
+	}
+	for _, c := range community {
+		used[c.index()] = true
+	}
+
+	heroBest := EvaluateBestHand(append(append([]Card{}, hole...), community...))
+	for _, combo := range villainRange {
+		if used[combo[0].index()] || used[combo[1].index()] || combo[0].index() == combo[1].index() {
+			continue
+		}
+		switch cmp := CompareHandValues(heroBest, EvaluateBestHand(append(combo[:], community...))); {
+		case cmp > 0:
+			ahead++
+		case cmp == 0:
+			tied++
+		default:
+			behind++
+		}
+	}
+	return ahead, tied, behind
+}
+
+// Spot classifications returned by WayAheadWayBehind.
+const (
+	SpotWayAheadWayBehind = "way ahead/way behind"
+	SpotMarginal          = "marginal"
+)
+
+// wawbEdge and wawbShare tune WayAheadWayBehind: a combo is decided when
+// hero's equity against it is at most wawbEdge or at least 1-wawbEdge, and
+// a spot is way ahead/way behind when at least wawbShare of the range is
+// decided.
+const (
+	wawbEdge  = 0.25
+	wawbShare = 0.8
+)
+
+// WayAheadWayBehind classifies hero's spot against villainRange on a 3-, 4-
+// or 5-card board by the shape of hero's equity against each combo, with
+// every runout enumerated. When nearly every combo leaves hero a big
+// favourite or a big underdog (an overpair against sets and air) it returns
+// SpotWayAheadWayBehind; otherwise SpotMarginal. Combos that share a card
+// with hero or the board are skipped; an empty range is SpotMarginal.
+func WayAheadWayBehind(hole, community []Card, villainRange [][2]Card) string {
+	if len(hole) != 2 {
+		panic("hole must have length 2")
+	}
+	if len(community) < 3 || len(community) > 5 {
+		panic("community must be 3, 4, or 5 cards")
+	}
+
+	var used [52]bool
+	for _, c := range hole {
+		used[c.index()] = true
+	}
+	for _, c := range community {
+		used[c.index()] = true
+	}
+
+	valid, decided := 0, 0
+	for _, combo := range villainRange {
+		if used[combo[0].index()] || used[combo[1].index()] || combo[0].index() == combo[1].index() {
+			continue
+		}
+		var won, tied, runouts int
+		heroCards := make([]Card, 7)
+		villainCards := make([]Card, 7)
+		copy(heroCards, hole)
+		copy(villainCards, combo[:])
+		forEachRunout([]Card{hole[0], hole[1], combo[0], combo[1]}, community, func(full []Card) {
+			copy(heroCards[2:], full)
+			copy(villainCards[2:], full)
+			switch cmp := CompareHandValues(EvaluateBestHand(heroCards), EvaluateBestHand(villainCards)); {
+			case cmp > 0:
+				won++
+			case cmp == 0:
+				tied++
+			}
+			runouts++
+		})
+		equity := (float64(won) + float64(tied)/2) / float64(runouts)
+		valid++
+		if equity <= wawbEdge || equity >= 1-wawbEdge {
+			decided++
+		}
+	}
+	if valid == 0 || float64(decided) < wawbShare*float64(valid) {
+		return SpotMarginal
+	}
+	return SpotWayAheadWayBehind
+}
+
+// RangeComboCount returns how many combos in r remain possible once the
+// blocker cards are known, i.e. those that share no card with blockers.
+// Combos holding the same card twice are never possible.
+func RangeComboCount(r [][2]Card, blockers []Card) int {
+	var used [52]bool
+	for _, c := range blockers {
+		used[c.index()] = true
+	}
+
+	n := 0
+	for _, combo := range r {
+		if used[combo[0].index()] || used[combo[1].index()] || combo[0].index() == combo[1].index() {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// DedupeCombos returns r with every combo after its first occurrence
+// dropped, whatever the order of its two cards, so overlapping entries
+// such as "AK" and "AKs" count each combo once.
+func DedupeCombos(r [][2]Card) [][2]Card {
+	seen := make(map[string]bool, len(r))
+	var out [][2]Card
+	for _, combo := range r {
+		key := CanonicalKey(combo[:])
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, combo)
+	}
+	return out
+}
+
+// RangeVsRangeEquity estimates hero's average equity (0-1, ties counted as
+// half) when hero holds a random combo from heroRange and a single villain a
+// random combo from villainRange. Combos that collide with the board or
+// hero's combo are never drawn; board may hold 0, 3, 4, or 5 cards.
+func RangeVsRangeEquity(heroRange, villainRange [][2]Card, board []Card, trials int) float64 {
+	return WeightedRangeVsRangeEquity(WeightedRange{Combos: heroRange}, WeightedRange{Combos: villainRange}, board, trials)
+}
+
+// WeightedRangeVsRangeEquity is like RangeVsRangeEquity but draws each
+// player's combos in proportion to their weights.
+func WeightedRangeVsRangeEquity(heroRange, villainRange WeightedRange, board []Card, trials int) float64 {
+	if len(board) != 0 && len(board) != 3 && len(board) != 4 && len(board) != 5 {
+		panic("board must be 0, 3, 4, or 5 cards")
+	}
+	if trials <= 0 || len(heroRange.Combos) == 0 || len(villainRange.Combos) == 0 {
+		return 0
+	}
+
+	hero := newComboSampler(heroRange.Combos, heroRange.Weights)
+	villain := newComboSampler(villainRange.Combos, villainRange.Weights)
+	res := runParallel(time.Now().UnixNano(), trials, 0, nil, func(rng *rand.Rand, local *SimulationResult, n int) {
+		for i := 0; i < n; i++ {
+			var used [52]bool
+			for _, c := range board {
+				used[c.index()] = true
+			}
+			heroCombo, ok := hero.draw(rng, &used)
+			if !ok {
+				continue
+			}
+			if heroWin, villainWin, tie, ok := playOutVsRange(rng, heroCombo[:], board, villain, 1); ok {
+				local.record(heroWin, villainWin, tie)
+			}
+		}
+	})
+	if res.TrialsRun == 0 {
+		return 0
+	}
+	return (float64(res.HeroWins) + float64(res.Ties)/2) / float64(res.TrialsRun)
+}
+
+// comboSampler draws combos from a range, each with probability
+// proportional to its weight.
+type comboSampler struct {
+	combos  [][2]Card
+	weights []float64 // nil draws every combo equally often
+}
+
+func newComboSampler(combos [][2]Card, weights []float64) comboSampler {
+	return comboSampler{combos: combos, weights: weights}
+}
+
+// weight returns combo i's weight, or 0 if one of its cards is used.
+func (s comboSampler) weight(i int, used *[52]bool) float64 {
+	combo := s.combos[i]
+	if used[combo[0].index()] || used[combo[1].index()] || combo[0].index() == combo[1].index() {
+		return 0
+	}
+	if s.weights == nil {
+		return 1
+	}
+	return s.weights[i]
+}
+
+// draw picks a combo whose cards are not yet used, with probability
+// proportional to its weight among those combos, and marks its cards as
+// used. It reports false only if every combo with a positive weight is
+// blocked.
+func (s comboSampler) draw(rng *rand.Rand, used *[52]bool) ([2]Card, bool) {
+	total := 0.0
+	for i := range s.combos {
+		total += s.weight(i, used)
+	}
+	if total <= 0 {
+		return [2]Card{}, false
+	}
+
+	// Walk the cumulative weights to the one that covers x.
+	x := rng.Float64() * total
+	pick := -1
+	for i := range s.combos {
+		w := s.weight(i, used)
+		if w <= 0 {
+			continue
+		}
+		pick = i
+		if x -= w; x < 0 {
+			break
+		}
+	}
+	combo := s.combos[pick]
+	used[combo[0].index()] = true
+	used[combo[1].index()] = true
+	return combo, true
+}
@@ -0,0 +1,221 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// combosOf expands starting hand class names into their combos.
+func combosOf(t testing.TB, names ...string) [][2]Card {
+	t.Helper()
+	var out [][2]Card
+	for _, name := range names {
+		combos, err := parseRangeEntry(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, combos...)
+	}
+	return out
+}
+
+func hasCombo(r [][2]Card, a, b Card) bool {
+	for _, c := range r {
+		if (c[0] == a && c[1] == b) || (c[0] == b && c[1] == a) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTopPercentRange(t *testing.T) {
+	top := TopPercentRange(5)
+	if len(top) < 66 {
+		t.Errorf("top 5%% has %d combos, want at least 66", len(top))
+	}
+	c := mustCards(t, "Ah", "As", "Kd", "7c", "2d")
+	if !hasCombo(top, c[0], c[1]) || !hasCombo(top, c[0], c[2]) {
+		t.Error("top 5% is missing AA or AKo")
+	}
+	if hasCombo(top, c[3], c[4]) {
+		t.Error("top 5% contains 72o")
+	}
+	if got := TopPercentRange(0); got != nil {
+		t.Errorf("TopPercentRange(0) = %d combos", len(got))
+	}
+	if got := len(TopPercentRange(100)); got != totalCombos {
+		t.Errorf("TopPercentRange(100) = %d combos", got)
+	}
+}
+
+func TestRangeVsRangeEquity(t *testing.T) {
+	strong, weak := combosOf(t, "AA", "KK"), combosOf(t, "72o", "32o")
+	if got := RangeVsRangeEquity(strong, weak, nil, 4000); got < 0.75 {
+		t.Errorf("strong vs weak = %v", got)
+	}
+	if got := RangeVsRangeEquity(weak, strong, nil, 4000); got > 0.25 {
+		t.Errorf("weak vs strong = %v", got)
+	}
+	if got := RangeVsRangeEquity(strong, nil, nil, 4000); got != 0 {
+		t.Errorf("empty villain range = %v", got)
+	}
+}
+
+func TestDownWeightingStrongCombosRaisesEquity(t *testing.T) {
+	hero := mustCards(t, "Qh", "Qd")
+	villain, err := ParseWeightedRange("AA, 22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weighted, err := ParseWeightedRange("AA:0.1, 22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	equity := func(r WeightedRange) float64 {
+		res := SimulateEquityWithOptions(hero, nil, 1, 20000, SimulationOptions{VillainRange: r.Combos, VillainWeights: r.Weights, Seed: 7})
+		win, _, tie := res.Rates()
+		return win + tie/2
+	}
+	flat, down := equity(villain), equity(weighted)
+	if down < flat+0.15 {
+		t.Errorf("down-weighting AA moved equity from %v to %v", flat, down)
+	}
+
+	heroRange := WeightedRange{Combos: [][2]Card{{hero[0], hero[1]}}}
+	if a, b := WeightedRangeVsRangeEquity(heroRange, villain, nil, 20000), WeightedRangeVsRangeEquity(heroRange, weighted, nil, 20000); b < a+0.15 {
+		t.Errorf("range vs range: down-weighting AA moved equity from %v to %v", a, b)
+	}
+}
+
+func TestComboSamplerSkipsBlockedCombos(t *testing.T) {
+	r, err := ParseWeightedRange("AA, KK:0.25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newComboSampler(r.Combos, r.Weights)
+
+	var used [52]bool
+	for _, c := range mustCards(t, "Ah", "Ad", "Ac") {
+		used[c.index()] = true
+	}
+	// With three aces known no AA combo is possible; every draw is a king.
+	for i := 0; i < 100; i++ {
+		u := used
+		combo, ok := s.draw(rand.New(rand.NewSource(int64(i))), &u)
+		if !ok || combo[0].Rank != King {
+			t.Fatalf("draw = %v, %v", combo, ok)
+		}
+	}
+	for _, c := range mustCards(t, "Kh", "Kd", "Kc") {
+		used[c.index()] = true
+	}
+	if combo, ok := s.draw(rand.New(rand.NewSource(1)), &used); ok {
+		t.Errorf("draw with every combo blocked = %v", combo)
+	}
+}
+
+func TestExactRangeEquity(t *testing.T) {
+	hero := mustCards(t, "8c", "Td")
+	board := mustCards(t, "2c", "7d", "9h", "Js", "4c")
+	villain := [][2]Card{
+		{mustCards(t, "Ah")[0], mustCards(t, "Ad")[0]},
+		{mustCards(t, "8h")[0], mustCards(t, "Th")[0]},
+		{mustCards(t, "2c")[0], mustCards(t, "3d")[0]},
+	}
+	res := ExactRangeEquity(hero, board, villain)
+	if res.Method != MethodExact || res.TrialsRun != 2 || res.HeroWins != 1 || res.Ties != 1 {
+		t.Errorf("ExactRangeEquity = %+v", res)
+	}
+}
+
+func TestDedupeCombos(t *testing.T) {
+	ak := mustCards(t, "Ah", "Kd")
+	qq := mustCards(t, "Qc", "Qd")
+	r := [][2]Card{{ak[0], ak[1]}, {qq[0], qq[1]}, {ak[1], ak[0]}, {ak[0], ak[1]}}
+	if got := DedupeCombos(r); len(got) != 2 || got[0] != r[0] || got[1] != r[1] {
+		t.Errorf("DedupeCombos = %v", got)
+	}
+}
+
+func TestCurrentStanding(t *testing.T) {
+	hero := mustCards(t, "Ah", "Ad")
+	flop := mustCards(t, "2c", "7d", "9h")
+	villain := [][2]Card{
+		{mustCards(t, "Kh")[0], mustCards(t, "Kd")[0]},
+		{mustCards(t, "7c")[0], mustCards(t, "7h")[0]},
+		{mustCards(t, "Ac")[0], mustCards(t, "As")[0]},
+		{mustCards(t, "Ah")[0], mustCards(t, "Kc")[0]},
+	}
+	if ahead, tied, behind := CurrentStanding(hero, flop, villain); ahead != 1 || tied != 1 || behind != 1 {
+		t.Errorf("CurrentStanding = %d, %d, %d, want 1, 1, 1", ahead, tied, behind)
+	}
+	if got := HandPercentileVsRange(hero, flop, villain); got != 0.5 {
+		t.Errorf("HandPercentileVsRange = %v, want 0.5", got)
+	}
+	if got := HandPercentileVsRange(hero, flop, villain[3:]); got != 0 {
+		t.Errorf("all combos blocked: HandPercentileVsRange = %v, want 0", got)
+	}
+}
+
+func TestWayAheadWayBehind(t *testing.T) {
+	tests := []struct {
+		name            string
+		hole, community []string
+		villain         []string
+		want            string
+	}{
+		{"overpair vs sets and air", []string{"Qh", "Qd"}, []string{"Kc", "7d", "2s"}, []string{"77", "22", "43o"}, SpotWayAheadWayBehind},
+		{"overcards vs a draw", []string{"Ah", "Kd"}, []string{"Th", "9c", "2d"}, []string{"QJs"}, SpotMarginal},
+		{"empty range", []string{"Ah", "Kd"}, []string{"Th", "9c", "2d"}, nil, SpotMarginal},
+	}
+	for _, tt := range tests {
+		got := WayAheadWayBehind(mustCards(t, tt.hole...), mustCards(t, tt.community...), combosOf(t, tt.villain...))
+		if got != tt.want {
+			t.Errorf("%s: WayAheadWayBehind = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRangeComboCount(t *testing.T) {
+	aces := combosOf(t, "AA")
+	tests := []struct {
+		blockers []string
+		want     int
+	}{
+		{nil, 6},
+		{[]string{"Ah"}, 3},
+		{[]string{"Ah", "Ad"}, 1},
+		{[]string{"Ah", "Ad", "Ac"}, 0},
+		{[]string{"Kh"}, 6},
+	}
+	for _, tt := range tests {
+		if got := RangeComboCount(aces, mustCards(t, tt.blockers...)); got != tt.want {
+			t.Errorf("RangeComboCount(AA, %v) = %d, want %d", tt.blockers, got, tt.want)
+		}
+	}
+	ah := mustCards(t, "Ah")[0]
+	if got := RangeComboCount([][2]Card{{ah, ah}}, nil); got != 0 {
+		t.Errorf("combo holding a card twice counted: %d", got)
+	}
+}
+
+func TestRangeAssumptionEquity(t *testing.T) {
+	random, reasonable := RangeAssumptionEquity(mustCards(t, "7c", "2d"), nil, 5000, 1)
+	if reasonable >= random {
+		t.Errorf("72o: %v against any two, %v against a reasonable range", random, reasonable)
+	}
+}
+
+func TestSimulateEquityVsRange(t *testing.T) {
+	hero := mustCards(t, "Ah", "Ad")
+	if res := SimulateEquityVsRange(hero, nil, nil, 1, 1000); res.TrialsRun != 0 {
+		t.Errorf("empty range ran %d trials", res.TrialsRun)
+	}
+	res := SimulateEquityVsRange(hero, nil, combosOf(t, "KK"), 1, 1000)
+	if win, _, _ := res.Rates(); res.TrialsRun != 1000 || win < 0.7 {
+		t.Errorf("AA vs KK: %+v", res)
+	}
+	mustPanic(t, "omaha range", func() {
+		SimulateEquityWithOptions(mustCards(t, "Ah", "Ad", "Kh", "Kd"), nil, 1, 10, SimulationOptions{Game: Omaha, VillainRange: combosOf(t, "QQ")})
+	})
+}
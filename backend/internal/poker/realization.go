@@ -0,0 +1,45 @@
+package poker
+
+// RealizedEquity scales a raw all-in equity (0-1) by a heuristic
+// equity-realization factor. Hands that are forced to fold before showdown
+// win less often than their raw equity suggests, and acting last lets a
+// player realize more of it.
+//
+// This is a simplified HEURISTIC, not a solver output. The factor is the
+// product of:
+//
+//	position: "ip" (in position) 1.05, "oop" (out of position) 0.85
+//	handType: "made" 1.00, "draw" 0.90, "speculative" 0.80, "weak" 0.70
+//
+// Unknown positions or hand types use a factor of 1. The result is clamped
+// to [0, 1].
+func RealizedEquity(rawEquity float64, position string, handType string) float64 {
+	factor := 1.0
+	if f, ok := positionRealization[position]; ok {
+		factor *= f
+	}
+	if f, ok := handTypeRealization[handType]; ok {
+		factor *= f
+	}
+
+	eq := rawEquity * factor
+	if eq < 0 {
+		return 0
+	}
+	if eq > 1 {
+		return 1
+	}
+	return eq
+}
+
+var positionRealization = map[string]float64{
+	"ip":  1.05,
+	"oop": 0.85,
+}
+
+var handTypeRealization = map[string]float64{
+	"made":        1.00,
+	"draw":        0.90,
+	"speculative": 0.80,
+	"weak":        0.70,
+}
@@ -0,0 +1,25 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRealizedEquity(t *testing.T) {
+	tests := []struct {
+		raw                float64
+		position, handType string
+		want               float64
+	}{
+		{0.5, "ip", "made", 0.525},
+		{0.5, "oop", "draw", 0.5 * 0.85 * 0.9},
+		{0.5, "button", "monster", 0.5},
+		{0.99, "ip", "made", 1},
+		{-0.1, "", "", 0},
+	}
+	for _, tt := range tests {
+		if got := RealizedEquity(tt.raw, tt.position, tt.handType); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("RealizedEquity(%v, %q, %q) = %v, want %v", tt.raw, tt.position, tt.handType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package poker
+
+import "math/rand"
+
+// RNG is a pseudo-random generator that simulations can draw from instead
+// of math/rand's default source; see SimulationOptions.NewRNG. Shuffles and
+// other draws are built on top of it by a *rand.Rand, so a generator whose
+// stream is fixed keeps seeded results reproducible across Go versions.
+type RNG interface {
+	// Uint64 returns the next 64 uniformly random bits.
+	Uint64() uint64
+}
+
+// NewStdRNG returns math/rand's default source seeded with seed, as used
+// when SimulationOptions.NewRNG is nil.
+func NewStdRNG(seed int64) RNG {
+	return rand.NewSource(seed).(rand.Source64)
+}
+
+// Xoshiro256 is the xoshiro256** generator by Blackman and Vigna: fast,
+// statistically strong and defined independently of the Go release.
+type Xoshiro256 struct {
+	s [4]uint64
+}
+
+// NewXoshiro256 returns a Xoshiro256 whose state is expanded from seed with
+// SplitMix64, as its authors recommend.
+func NewXoshiro256(seed int64) RNG {
+	x := &Xoshiro256{}
+	sm := uint64(seed)
+	for i := range x.s {
+		sm += 0x9e3779b97f4a7c15
+		z := sm
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		x.s[i] = z ^ (z >> 31)
+	}
+	return x
+}
+
+// Uint64 implements RNG.
+func (x *Xoshiro256) Uint64() uint64 {
+	s := &x.s
+	result := rotl(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = rotl(s[3], 45)
+	return result
+}
+
+func rotl(x uint64, k uint) uint64 {
+	return (x << k) | (x >> (64 - k))
+}
+
+// newRand returns a *rand.Rand drawing from newRNG(seed), or from
+// math/rand's default source if newRNG is nil.
+func newRand(newRNG func(seed int64) RNG, seed int64) *rand.Rand {
+	if newRNG == nil {
+		return rand.New(rand.NewSource(seed))
+	}
+	return rand.New(rngSource{newRNG(seed)})
+}
+
+// rngSource adapts an RNG to rand.Source64.
+type rngSource struct {
+	rng RNG
+}
+
+func (s rngSource) Uint64() uint64 { return s.rng.Uint64() }
+func (s rngSource) Int63() int64   { return int64(s.rng.Uint64() >> 1) }
+
+// Seed is required by rand.Source; generators are seeded when created.
+func (s rngSource) Seed(int64) {
+	panic("rngSource cannot be reseeded")
+}
@@ -0,0 +1,42 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestXoshiro256Stream(t *testing.T) {
+	// Reference outputs of xoshiro256** seeded through SplitMix64 from 0.
+	want := []uint64{0x99ec5f36cb75f2b4, 0xbf6e1f784956452a, 0x1a5f849d4933e6e0}
+	rng := NewXoshiro256(0)
+	for i, w := range want {
+		if got := rng.Uint64(); got != w {
+			t.Errorf("output %d = %#x, want %#x", i, got, w)
+		}
+	}
+	if NewXoshiro256(1).Uint64() == NewXoshiro256(2).Uint64() {
+		t.Error("seeds 1 and 2 start the same stream")
+	}
+}
+
+func TestSimulationWithXoshiroIsReproducible(t *testing.T) {
+	hole := mustCards(t, "Ah", "Kd")
+	opts := SimulationOptions{Seed: 9, NewRNG: NewXoshiro256}
+	a := SimulateEquityWithOptions(hole, nil, 2, 5000, opts)
+	b := SimulateEquityWithOptions(hole, nil, 2, 5000, opts)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("same seed: %+v vs %+v", a, b)
+	}
+	if a.TrialsRun != 5000 {
+		t.Errorf("TrialsRun = %d", a.TrialsRun)
+	}
+}
+
+func TestRNGSourceCannotBeReseeded(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Seed did not panic")
+		}
+	}()
+	rngSource{NewXoshiro256(1)}.Seed(2)
+}
@@ -0,0 +1,20 @@
+package poker
+
+import "math/rand"
+
+// RandomHands returns n random 7-card hands, each dealt from its own fresh
+// deck so no hand contains the same card twice. It is intended for fuzzing
+// and benchmarking the evaluator.
+func RandomHands(n int, rng *rand.Rand) [][]Card {
+	deck := FullDeck()
+	hands := make([][]Card, n)
+	for i := range hands {
+		// Partial Fisher-Yates: the first 7 positions become the hand.
+		for j := 0; j < 7; j++ {
+			k := j + rng.Intn(len(deck)-j)
+			deck[j], deck[k] = deck[k], deck[j]
+		}
+		hands[i] = append([]Card(nil), deck[:7]...)
+	}
+	return hands
+}
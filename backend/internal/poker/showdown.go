@@ -0,0 +1,68 @@
+package poker
+
+import "sort"
+
+// RankHands evaluates every player's hole cards against a complete 5-card
+// board and groups player indexes from best to worst. Players in the same
+// group tie; indexes within a group are ascending. A player with no hole
+// cards has folded: their index appears in no group, but other players keep
+// their original indexes.
+func RankHands(holes [][]Card, board []Card) [][]int {
+	if len(board) != 5 {
+		panic("RankHands requires a 5-card board")
+	}
+
+	values := make([]HandValue, len(holes))
+	order := make([]int, 0, len(holes))
+	for i, h := range holes {
+		if len(h) == 0 {
+			continue
+		}
+		values[i] = Holdem.BestHand(h, board)
+		order = append(order, i)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return CompareHandValues(values[order[a]], values[order[b]]) > 0
+	})
+
+	var groups [][]int
+	for i, p := range order {
+		if i > 0 && CompareHandValues(values[p], values[order[i-1]]) == 0 {
+			groups[len(groups)-1] = append(groups[len(groups)-1], p)
+			continue
+		}
+		groups = append(groups, []int{p})
+	}
+	return groups
+}
+
+// PlayerEquity is one player's result at showdown on a complete board.
+type PlayerEquity struct {
+	Player int     // index into the holes passed to EvaluateMultiway
+	Win    bool    // wins the pot alone or shares it
+	Tie    bool    // shares the pot with at least one other player
+	Share  float64 // fraction of the pot won: 1, 1/N in an N-way chop, or 0
+}
+
+// EvaluateMultiway compares every player's hole cards on a complete 5-card
+// board and returns each player's exact share of a single pot, in player
+// order. Players who tie for the best hand split it evenly, so a three-way
+// chop gives each 1/3. A player with no hole cards has folded and wins
+// nothing; see RankHands.
+func EvaluateMultiway(holes [][]Card, community []Card) []PlayerEquity {
+	out := make([]PlayerEquity, len(holes))
+	for i := range out {
+		out[i].Player = i
+	}
+	groups := RankHands(holes, community)
+	if len(groups) == 0 {
+		return out
+	}
+	winners := groups[0]
+	for _, p := range winners {
+		out[p].Win = true
+		out[p].Tie = len(winners) > 1
+		out[p].Share = 1 / float64(len(winners))
+	}
+	return out
+}
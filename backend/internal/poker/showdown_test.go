@@ -0,0 +1,60 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRankHands(t *testing.T) {
+	board := mustCards(t, "2c", "7d", "9h", "Js", "4c")
+	holes := [][]Card{
+		mustCards(t, "Ah", "Ad"),
+		mustCards(t, "Ac", "As"),
+		mustCards(t, "Kh", "Kd"),
+		nil,
+		mustCards(t, "8c", "Td"),
+	}
+	want := [][]int{{4}, {0, 1}, {2}}
+	if got := RankHands(holes, board); !reflect.DeepEqual(got, want) {
+		t.Errorf("RankHands = %v, want %v", got, want)
+	}
+	if got := RankHands([][]Card{nil, nil}, board); len(got) != 0 {
+		t.Errorf("everyone folded: RankHands = %v", got)
+	}
+}
+
+func TestEvaluateMultiway(t *testing.T) {
+	tests := []struct {
+		name   string
+		board  []string
+		holes  [][]string
+		shares []float64
+	}{
+		{"three-way chop", []string{"Ah", "Kh", "Qh", "Jh", "Th"}, [][]string{{"2c", "3d"}, {"4c", "5d"}, {"6c", "7d"}}, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{"single winner", []string{"2c", "7d", "9h", "Js", "4c"}, [][]string{{"Ah", "Ad"}, {"8c", "Td"}, {"Kh", "Kd"}}, []float64{0, 1, 0}},
+		{"folded player", []string{"2c", "7d", "9h", "Js", "4c"}, [][]string{{"Ah", "Ad"}, nil, {"Kh", "Kd"}}, []float64{1, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holes := make([][]Card, len(tt.holes))
+			for i, h := range tt.holes {
+				if h != nil {
+					holes[i] = mustCards(t, h...)
+				}
+			}
+			got := EvaluateMultiway(holes, mustCards(t, tt.board...))
+			winners := 0
+			for _, s := range tt.shares {
+				if s > 0 {
+					winners++
+				}
+			}
+			for i, pe := range got {
+				want := PlayerEquity{Player: i, Win: tt.shares[i] > 0, Tie: tt.shares[i] > 0 && winners > 1, Share: tt.shares[i]}
+				if pe != want {
+					t.Errorf("player %d = %+v, want %+v", i, pe, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package poker
+
+import "sync"
+
+var (
+	tablesOnce sync.Once
+	tablesErr  error
+
+	// rankedStartingHands is preflopEquity as a list, strongest first.
+	rankedStartingHands []StartingHand
+
+	// handScores lists every distinct 5-card HandValue.Score, weakest
+	// first; scoreAtOrBelow[i] is the fraction of all 5-card hands whose
+	// score is at most handScores[i].
+	handScores     []int
+	scoreAtOrBelow []float64
+)
+
+// Init builds the package's precomputed lookup tables. Only the first call
+// does any work; later calls return the same result. Functions that need
+// the tables call Init themselves, so calling it at startup, possibly in a
+// background goroutine, only moves the cost off the first request.
+func Init() error {
+	tablesOnce.Do(func() {
+		tablesErr = buildTables()
+	})
+	return tablesErr
+}
+
+func buildTables() error {
+	hands, err := buildStartingHands()
+	if err != nil {
+		return err
+	}
+	rankedStartingHands = hands
+	handScores, scoreAtOrBelow = buildPercentileTable()
+	return nil
+}
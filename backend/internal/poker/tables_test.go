@@ -0,0 +1,19 @@
+package poker
+
+import "testing"
+
+func TestInit(t *testing.T) {
+	if err := Init(); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	if len(rankedStartingHands) != 169 {
+		t.Errorf("%d starting hands, want 169", len(rankedStartingHands))
+	}
+	if h := rankedStartingHands[0]; h.Name != "AA" {
+		t.Errorf("strongest starting hand = %s, want AA", h.Name)
+	}
+	royal := EvaluateBestHand(mustCards(t, "Ah", "Kh", "Qh", "Jh", "Th"))
+	if got, err := HandPercentile(royal); got != 1 || err != nil {
+		t.Errorf("royal flush percentile = %v, %v; want 1", got, err)
+	}
+}
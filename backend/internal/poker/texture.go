@@ -0,0 +1,173 @@
+package poker
+
+// BoardTexture holds the probabilities (0-1) that a partial board, once
+// completed to five cards, has the given property.
+type BoardTexture struct {
+	Paired        float64 // at least two cards share a rank
+	FlushPossible float64 // at least three cards share a suit
+	FourStraight  float64 // four distinct ranks fit within a five-rank window
+}
+
+// BoardTextureOdds enumerates every completion of a 3- or 4-card board and
+// reports how often the final board is paired, three-suited, or
+// four-to-a-straight. It is independent of any player's hole cards.
+func BoardTextureOdds(community []Card) BoardTexture {
+	if len(community) != 3 && len(community) != 4 {
+		panic("community must be 3 or 4 cards")
+	}
+
+	var paired, flush, straight, total int
+	forEachRunout(nil, community, func(board []Card) {
+		if boardPaired(board) {
+			paired++
+		}
+		if maxSuitCount(board) >= 3 {
+			flush++
+		}
+		if longestStraightWindow(board) >= 4 {
+			straight++
+		}
+		total++
+	})
+
+	return BoardTexture{
+		Paired:        float64(paired) / float64(total),
+		FlushPossible: float64(flush) / float64(total),
+		FourStraight:  float64(straight) / float64(total),
+	}
+}
+
+// PossibleMadeHands returns, in ascending order, every hand category that
+// some two-card holding makes right now with a 3-, 4- or 5-card board. For
+// example a rainbow flop cannot make a flush yet, while a paired board
+// makes full houses and quads possible.
+func PossibleMadeHands(community []Card) []int {
+	if len(community) < 3 || len(community) > 5 {
+		panic("community must be 3, 4, or 5 cards")
+	}
+
+	var possible [StraightFlush + 1]bool
+	for _, h := range RemainingHoldings(community) {
+		possible[EvaluateBestHand(append(h[:], community...)).Category] = true
+	}
+	var out []int
+	for cat, ok := range possible {
+		if ok {
+			out = append(out, cat)
+		}
+	}
+	return out
+}
+
+// WinningCategoryDistribution characterises a complete 5-card board by the
+// hand that wins a heads-up showdown: over every pair of disjoint two-card
+// holdings it returns the fraction (0-1) of showdowns won by each hand
+// category, keyed by category. A split pot counts for the category both
+// players share. Categories that never win are absent.
+func WinningCategoryDistribution(community []Card) map[int]float64 {
+	if len(community) != 5 {
+		panic("community must be 5 cards")
+	}
+
+	holdings := RemainingHoldings(community)
+	values := make([]HandValue, len(holdings))
+	scores := make([]int, len(holdings))
+	for i, h := range holdings {
+		values[i] = Holdem.BestHand(h[:], community)
+		scores[i] = values[i].Score()
+	}
+
+	var wins [StraightFlush + 1]int
+	showdowns := 0
+	for i, a := range holdings {
+		for j := i + 1; j < len(holdings); j++ {
+			b := holdings[j]
+			if a[0] == b[0] || a[0] == b[1] || a[1] == b[0] || a[1] == b[1] {
+				continue
+			}
+			winner := i
+			if scores[j] > scores[i] {
+				winner = j
+			}
+			wins[values[winner].Category]++
+			showdowns++
+		}
+	}
+
+	dist := make(map[int]float64)
+	for cat, n := range wins {
+		if n > 0 {
+			dist[cat] = float64(n) / float64(showdowns)
+		}
+	}
+	return dist
+}
+
+// BoardWetness scores how coordinated a 3-, 4- or 5-card board is, from 0
+// (dry) to 1 (wet). Flush and straight potential each contribute up to 0.4:
+// half for a two-suited board or two ranks within a straight window, all of
+// it for three of a suit or three ranks within a window, where some holding
+// has the draw or the made hand. An unpaired board adds the last 0.2, since
+// pairing takes ranks off the board and leaves fewer draws. A monotone
+// connected flop such as 9h8h7h scores 1; K72 rainbow scores 0.2.
+func BoardWetness(community []Card) float64 {
+	if len(community) < 3 || len(community) > 5 {
+		panic("community must be 3, 4, or 5 cards")
+	}
+	step := func(n int) float64 {
+		return min(max(float64(n-1)/2, 0), 1)
+	}
+	score := 0.4*step(maxSuitCount(community)) + 0.4*step(longestStraightWindow(community))
+	if !boardPaired(community) {
+		score += 0.2
+	}
+	return score
+}
+
+func boardPaired(cards []Card) bool {
+	var seen [Ace + 1]bool
+	for _, c := range cards {
+		if seen[c.Rank] {
+			return true
+		}
+		seen[c.Rank] = true
+	}
+	return false
+}
+
+func maxSuitCount(cards []Card) int {
+	var counts [4]int
+	best := 0
+	for _, c := range cards {
+		counts[c.Suit]++
+		if counts[c.Suit] > best {
+			best = counts[c.Suit]
+		}
+	}
+	return best
+}
+
+// longestStraightWindow returns the largest number of distinct ranks that
+// fall within any five consecutive ranks, treating the ace as both high and
+// low.
+func longestStraightWindow(cards []Card) int {
+	var seen [Ace + 1]bool
+	for _, c := range cards {
+		seen[c.Rank] = true
+	}
+	best := 0
+	// Window tops run from Five (A-2-3-4-5) up to Ace (T-J-Q-K-A).
+	for top := Five; top <= Ace; top++ {
+		n := 0
+		for r := top - 4; r <= top; r++ {
+			// Rank 1 stands in for a low ace.
+			if seen[r] || (r == 1 && seen[Ace]) {
+				n++
+			}
+		}
+		if n > best {
+			best = n
+		}
+	}
+	return best
+}
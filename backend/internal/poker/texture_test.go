@@ -0,0 +1,83 @@
+package poker
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBoardTextureOdds(t *testing.T) {
+	tests := []struct {
+		name      string
+		community []string
+		want      BoardTexture
+	}{
+		// 12 of 48 river cards pair the board; no suit or straight window
+		// can reach the threshold.
+		{"rainbow turn", []string{"2c", "7d", "Kh", "9s"}, BoardTexture{Paired: 0.25}},
+		{"paired monotone flop", []string{"2h", "2d", "7h"}, BoardTexture{Paired: 1}},
+	}
+	for _, tt := range tests {
+		got := BoardTextureOdds(mustCards(t, tt.community...))
+		if math.Abs(got.Paired-tt.want.Paired) > 1e-12 || got.FourStraight != tt.want.FourStraight {
+			t.Errorf("%s: BoardTextureOdds = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if got := BoardTextureOdds(mustCards(t, "9h", "8h", "7h")); got.FlushPossible != 1 || got.FourStraight <= 0 {
+		t.Errorf("monotone connected flop: %+v", got)
+	}
+	if got := BoardTextureOdds(mustCards(t, "2c", "7d", "Kh", "9s")); got.FlushPossible != 0 {
+		t.Errorf("rainbow turn: FlushPossible = %v", got.FlushPossible)
+	}
+}
+
+func TestPossibleMadeHands(t *testing.T) {
+	tests := []struct {
+		community []string
+		want      []int
+	}{
+		{[]string{"2c", "7d", "Kh"}, []int{HighCard, OnePair, TwoPair, ThreeOfAKind}},
+		{[]string{"2c", "2d", "Kh"}, []int{OnePair, TwoPair, ThreeOfAKind, FullHouse, FourOfAKind}},
+		{[]string{"Ah", "Kh", "Qh", "Jh", "Th"}, []int{StraightFlush}},
+	}
+	for _, tt := range tests {
+		if got := PossibleMadeHands(mustCards(t, tt.community...)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PossibleMadeHands(%v) = %v, want %v", tt.community, got, tt.want)
+		}
+	}
+}
+
+func TestWinningCategoryDistribution(t *testing.T) {
+	if got := WinningCategoryDistribution(mustCards(t, "Ah", "Kh", "Qh", "Jh", "Th")); !reflect.DeepEqual(got, map[int]float64{StraightFlush: 1}) {
+		t.Errorf("royal flush board: %v", got)
+	}
+	dist := WinningCategoryDistribution(mustCards(t, "2c", "7d", "9h", "Js", "4c"))
+	sum := 0.0
+	for cat, p := range dist {
+		if cat > Straight || p <= 0 {
+			t.Errorf("unexpected winning category %d with share %v", cat, p)
+		}
+		sum += p
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("shares sum to %v", sum)
+	}
+}
+
+func TestBoardWetness(t *testing.T) {
+	tests := []struct {
+		community []string
+		want      float64
+	}{
+		{[]string{"9h", "8h", "7h"}, 1},
+		{[]string{"Kc", "7d", "2s"}, 0.2},
+		{[]string{"Kc", "Kd", "2s"}, 0},
+		{[]string{"Kh", "Qh", "2s"}, 0.2 + 0.2 + 0.2},
+	}
+	for _, tt := range tests {
+		if got := BoardWetness(mustCards(t, tt.community...)); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("BoardWetness(%v) = %v, want %v", tt.community, got, tt.want)
+		}
+	}
+}
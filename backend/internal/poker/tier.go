@@ -0,0 +1,55 @@
+package poker
+
+// Hand tiers returned by HandTier, strongest first.
+const (
+	TierNuts   = "nuts"
+	TierStrong = "strong"
+	TierMedium = "medium"
+	TierWeak   = "weak"
+	TierAir    = "air"
+)
+
+// Percentile cut-offs for HandTier. Against every possible holding most
+// hands miss the board, so even a weak pair beats well over half of them.
+const (
+	strongTierPercentile = 0.95
+	mediumTierPercentile = 0.80
+	airTierPercentile    = 0.50
+)
+
+// HandTier classifies hero's current made hand on a 3-, 4- or 5-card board
+// for coaching purposes, by its percentile (HandPercentileVsRange) against
+// every holding villain could have:
+//
+//	nuts    no holding beats hero right now
+//	strong  beats at least 95% (sets, two pair, top pair good kicker)
+//	medium  beats at least 80% (weaker top pairs, overpairs, middle pair)
+//	weak    any other pair or better
+//	air     high card, or below the 50th percentile
+func HandTier(hole, community []Card) string {
+	known := append(append([]Card{}, hole...), community...)
+	holdings := RemainingHoldings(known)
+	pct := HandPercentileVsRange(hole, community, holdings)
+
+	heroBest := EvaluateBestHand(known)
+	nuts := true
+	for _, h := range holdings {
+		if CompareHandValues(EvaluateBestHand(append(h[:], community...)), heroBest) > 0 {
+			nuts = false
+			break
+		}
+	}
+
+	switch {
+	case nuts:
+		return TierNuts
+	case pct >= strongTierPercentile:
+		return TierStrong
+	case pct >= mediumTierPercentile:
+		return TierMedium
+	case heroBest.Category == HighCard || pct < airTierPercentile:
+		return TierAir
+	default:
+		return TierWeak
+	}
+}
@@ -0,0 +1,19 @@
+package poker
+
+import "testing"
+
+func TestHandTier(t *testing.T) {
+	tests := []struct {
+		hole, community []string
+		want            string
+	}{
+		{[]string{"Ah", "Kc"}, []string{"Qd", "Jh", "Ts"}, TierNuts},
+		{[]string{"9c", "9d"}, []string{"9h", "6s", "7c"}, TierStrong},
+		{[]string{"7c", "2d"}, []string{"Ah", "Kh", "9s"}, TierAir},
+	}
+	for _, tt := range tests {
+		if got := HandTier(mustCards(t, tt.hole...), mustCards(t, tt.community...)); got != tt.want {
+			t.Errorf("HandTier(%v, %v) = %q, want %q", tt.hole, tt.community, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package poker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WeightedRange is a range whose combos are played with different
+// frequencies, e.g. AK always but QQ only half the time. Weights[i] is the
+// relative weight of Combos[i]; nil Weights weights every combo equally.
+type WeightedRange struct {
+	Combos  [][2]Card
+	Weights []float64
+}
+
+// ParseWeightedRange parses comma-separated range notation such as
+// "AKs, AKo, QQ:0.5, AhKd:0.25". Each entry is a starting hand class
+// ("QQ", "AKs", "AKo", or "AK" for both suited and offsuit) or a concrete
+// combo, optionally followed by ":w" with a weight between 0 and 1
+// (default 1). A combo covered by more than one entry, as in "AK, AKs:0.5",
+// takes the weight of the last. Combos weighted 0 are left out, and a range
+// with no combo of positive weight is an error.
+func ParseWeightedRange(s string) (WeightedRange, error) {
+	var all WeightedRange
+	index := make(map[string]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		hand, weight := entry, 1.0
+		if i := strings.IndexByte(entry, ':'); i >= 0 {
+			hand = entry[:i]
+			w, err := strconv.ParseFloat(entry[i+1:], 64)
+			if err != nil || w < 0 || w > 1 {
+				return WeightedRange{}, fmt.Errorf("invalid weight in range entry: %s", entry)
+			}
+			weight = w
+		}
+		combos, err := parseRangeEntry(hand)
+		if err != nil {
+			return WeightedRange{}, err
+		}
+		for _, c := range combos {
+			key := CanonicalKey(c[:])
+			if i, ok := index[key]; ok {
+				all.Weights[i] = weight
+				continue
+			}
+			index[key] = len(all.Combos)
+			all.Combos = append(all.Combos, c)
+			all.Weights = append(all.Weights, weight)
+		}
+	}
+
+	var r WeightedRange
+	for i, c := range all.Combos {
+		if all.Weights[i] > 0 {
+			r.Combos = append(r.Combos, c)
+			r.Weights = append(r.Weights, all.Weights[i])
+		}
+	}
+	if len(r.Combos) == 0 {
+		return WeightedRange{}, fmt.Errorf("range has no combo with a positive weight")
+	}
+	return r, nil
+}
+
+// parseRangeEntry expands a starting hand class or a concrete combo such as
+// "AhKd" into its combos.
+func parseRangeEntry(s string) ([][2]Card, error) {
+	if len(s) == 4 {
+		c1, err1 := ParseCard(s[:2])
+		c2, err2 := ParseCard(s[2:])
+		if err1 != nil || err2 != nil || c1 == c2 {
+			return nil, fmt.Errorf("invalid combo in range: %s", s)
+		}
+		return [][2]Card{{c1, c2}}, nil
+	}
+
+	if len(s) != 2 && len(s) != 3 {
+		return nil, fmt.Errorf("invalid range entry: %s", s)
+	}
+	hi, ok1 := parseRank(s[:1])
+	lo, ok2 := parseRank(s[1:2])
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("invalid range entry: %s", s)
+	}
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+	h := StartingHand{High: hi, Low: lo}
+	switch {
+	case len(s) == 2 && hi == lo:
+		return h.Combos(), nil
+	case len(s) == 2:
+		h.Suited = true
+		suited := h.Combos()
+		h.Suited = false
+		return append(suited, h.Combos()...), nil
+	case hi != lo && (s[2] == 's' || s[2] == 'o'):
+		h.Suited = s[2] == 's'
+		return h.Combos(), nil
+	}
+	return nil, fmt.Errorf("invalid range entry: %s", s)
+}
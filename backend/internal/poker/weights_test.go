@@ -0,0 +1,46 @@
+package poker
+
+import "testing"
+
+func TestParseWeightedRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		combos  int
+		weights map[float64]int // weight -> number of combos
+	}{
+		{"AKs, AKo, QQ:0.5, AhKd:0.25", 22, map[float64]int{1: 15, 0.5: 6, 0.25: 1}},
+		{"AK", 16, map[float64]int{1: 16}},
+		{"KA, 22:1", 22, map[float64]int{1: 22}},
+		{"QQ:0, JJ", 6, map[float64]int{1: 6}},
+		{" TT , ,T9s ", 10, map[float64]int{1: 10}},
+		{"AK, AKs", 16, map[float64]int{1: 16}},
+		{"AK, AKs:0.5, AhKh:0", 15, map[float64]int{1: 12, 0.5: 3}},
+	}
+	for _, tt := range tests {
+		r, err := ParseWeightedRange(tt.in)
+		if err != nil {
+			t.Errorf("ParseWeightedRange(%q): %v", tt.in, err)
+			continue
+		}
+		if len(r.Combos) != tt.combos || len(r.Weights) != tt.combos {
+			t.Errorf("ParseWeightedRange(%q): %d combos, %d weights, want %d", tt.in, len(r.Combos), len(r.Weights), tt.combos)
+		}
+		got := make(map[float64]int)
+		for _, w := range r.Weights {
+			got[w]++
+		}
+		for w, n := range tt.weights {
+			if got[w] != n {
+				t.Errorf("ParseWeightedRange(%q): %d combos weighted %v, want %d", tt.in, got[w], w, n)
+			}
+		}
+	}
+}
+
+func TestParseWeightedRangeErrors(t *testing.T) {
+	for _, in := range []string{"", "QQ:0", "QQ:2", "QQ:-0.5", "QQ:x", "AKx", "QQs", "ZZ", "AhAh", "AhZz", "AKQJT"} {
+		if r, err := ParseWeightedRange(in); err == nil {
+			t.Errorf("ParseWeightedRange(%q) = %d combos, want error", in, len(r.Combos))
+		}
+	}
+}
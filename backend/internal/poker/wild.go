@@ -0,0 +1,75 @@
+package poker
+
+import "fmt"
+
+// WildCard is the placeholder ParseCardOrWild returns for "?": a card that
+// stands for whichever card makes the best hand. It has no suit or rank, so
+// only EvaluateBestHandWithWilds and HasDuplicates, which skips it,
+// understand it; every other function expects real cards.
+var WildCard = Card{Str: "?"}
+
+// ParseCardOrWild is ParseCard that also accepts "?" as WildCard. It is a
+// separate function rather than a ParseCard option because ParseCard feeds
+// every endpoint and the card-indexed tables behind them; a caller has to
+// ask for wildcards to get one.
+func ParseCardOrWild(s string) (Card, error) {
+	if s == WildCard.Str {
+		return WildCard, nil
+	}
+	return ParseCard(s)
+}
+
+// MaxWilds bounds the wildcards EvaluateBestHandWithWilds accepts, since
+// each one multiplies the substitutions to try by about 45.
+const MaxWilds = 2
+
+// IsWild reports whether c is the WildCard placeholder.
+func (c Card) IsWild() bool {
+	return c.Str == WildCard.Str
+}
+
+// EvaluateBestHandWithWilds is like EvaluateBestHand for exactly 7 cards,
+// any of which may be WildCard. Every wildcard is replaced by each card not otherwise in
+// the hand (two wildcards by two different cards) and the best resulting
+// hand is returned. At most MaxWilds wildcards are allowed.
+func EvaluateBestHandWithWilds(cards []Card) (HandValue, error) {
+	if len(cards) != 7 {
+		return HandValue{}, fmt.Errorf("need exactly 7 cards, got %d", len(cards))
+	}
+
+	var fixed []Card
+	wilds := 0
+	for _, c := range cards {
+		if c.IsWild() {
+			wilds++
+		} else {
+			fixed = append(fixed, c)
+		}
+	}
+	if wilds > MaxWilds {
+		return HandValue{}, fmt.Errorf("at most %d wildcards are supported, got %d", MaxWilds, wilds)
+	}
+	if HasDuplicates(fixed) {
+		return HandValue{}, fmt.Errorf("duplicate cards")
+	}
+
+	var best HandValue
+	found := false
+	hand := append(make([]Card, 0, 7), fixed...)
+	var try func(deck []Card, left int)
+	try = func(deck []Card, left int) {
+		if left == 0 {
+			if hv := EvaluateBestHand(hand); !found || CompareHandValues(hv, best) > 0 {
+				best, found = hv, true
+			}
+			return
+		}
+		for i, c := range deck {
+			hand = append(hand, c)
+			try(deck[i+1:], left-1)
+			hand = hand[:len(hand)-1]
+		}
+	}
+	try(remainingDeck(fixed), wilds)
+	return best, nil
+}
@@ -0,0 +1,73 @@
+package poker
+
+import "testing"
+
+func TestParseCardOrWild(t *testing.T) {
+	c, err := ParseCardOrWild("?")
+	if err != nil || !c.IsWild() {
+		t.Fatalf("ParseCardOrWild(\"?\") = %v, %v", c, err)
+	}
+	c, err = ParseCardOrWild("Ah")
+	if err != nil || c.IsWild() || c != newCard(Hearts, Ace) {
+		t.Fatalf("ParseCardOrWild(\"Ah\") = %v, %v", c, err)
+	}
+	if _, err := ParseCardOrWild("??"); err == nil {
+		t.Error("ParseCardOrWild(\"??\") succeeded")
+	}
+	if _, err := ParseCard("?"); err == nil {
+		t.Error("ParseCard(\"?\") succeeded")
+	}
+}
+
+func TestHasDuplicatesSkipsWilds(t *testing.T) {
+	ah := newCard(Hearts, Ace)
+	if HasDuplicates([]Card{WildCard, ah, WildCard}) {
+		t.Error("two wildcards counted as duplicates")
+	}
+	if !HasDuplicates([]Card{WildCard, ah, ah}) {
+		t.Error("duplicate ace beside a wildcard missed")
+	}
+}
+
+func TestEvaluateBestHandWithWilds(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    []string
+		category int
+		kickers  []Rank
+		wantErr  bool
+	}{
+		{"no wilds", []string{"Ah", "Kd", "Qc", "Js", "9h", "3c", "2d"}, HighCard, []Rank{Ace, King, Queen, Jack, Nine}, false},
+		{"wild fills a straight", []string{"?", "Kd", "Qc", "Js", "9h", "3c", "2d"}, Straight, []Rank{King}, false},
+		{"wild makes trips a full house", []string{"?", "Ad", "Ac", "Ks", "Kh", "3c", "2d"}, FullHouse, []Rank{Ace, King}, false},
+		{"two wilds make a royal flush", []string{"?", "?", "Ah", "Kh", "Qh", "3c", "2d"}, StraightFlush, []Rank{Ace}, false},
+		{"too many wilds", []string{"?", "?", "?", "Kh", "Qh", "3c", "2d"}, 0, nil, true},
+		{"wrong count", []string{"?", "Kh", "Qh", "3c", "2d"}, 0, nil, true},
+		{"duplicates", []string{"?", "Kh", "Kh", "3c", "2d", "7s", "8s"}, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cards []Card
+			for _, s := range tt.cards {
+				c, err := ParseCardOrWild(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				cards = append(cards, c)
+			}
+			got, err := EvaluateBestHandWithWilds(cards)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := (HandValue{Category: tt.category, Kickers: tt.kickers}); !sameHandValue(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}